@@ -0,0 +1,172 @@
+package fabclient
+
+import (
+	"context"
+	"time"
+)
+
+// BatchRequest is transaction of batch. ChaincodeID is ignored by Chaincode Client
+type BatchRequest struct {
+	ChaincodeID  string
+	FunctionName string
+	Args         [][]byte
+}
+
+// BatchResult is result of transaction of batch. Index is position of request in batch
+type BatchResult struct {
+	Index   int
+	Receipt *Receipt
+	Err     error
+}
+
+// BatchOptions limits concurrency of batch
+type BatchOptions struct {
+	// Parallelism is maximum number of transactions invoked concurrently. Values less than 1 mean 1
+	Parallelism int
+	// RateLimit is maximum number of transactions sent per second. Zero disables limit
+	RateLimit float64
+}
+
+func (o BatchOptions) parallelism() int {
+	if o.Parallelism < 1 {
+		return 1
+	}
+	return o.Parallelism
+}
+
+// rateLimiter spaces transactions evenly. It is used by single dispatching goroutine
+type rateLimiter struct {
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(rate float64) *rateLimiter {
+	if rate <= 0 {
+		return &rateLimiter{}
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / rate)}
+}
+
+// wait blocks until next transaction may be sent. ctx.Err() is returned if ctx is done earlier
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l.interval == 0 {
+		return ctx.Err()
+	}
+	now := time.Now()
+	if l.next.After(now) {
+		timer := time.NewTimer(l.next.Sub(now))
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		now = l.next
+	}
+	l.next = now.Add(l.interval)
+	return nil
+}
+
+// InvokeBatch invokes transactions concurrently according to batchOptions and returns results in order of requests.
+// Failed transactions do not abort batch, error of every transaction is returned in its result
+func (c *UserClient) InvokeBatch(requests []BatchRequest, batchOptions BatchOptions, opts ...Option) []BatchResult {
+	return c.InvokeBatchContext(context.Background(), requests, batchOptions, opts...)
+}
+
+// InvokeBatchContext is the same as InvokeBatch but transactions are bound to ctx. If ctx is done remaining transactions fail with ctx.Err()
+func (c *UserClient) InvokeBatchContext(ctx context.Context, requests []BatchRequest, batchOptions BatchOptions, opts ...Option) []BatchResult {
+	results := make([]BatchResult, 0, len(requests))
+	for result := range c.InvokeBatchStreamContext(ctx, batchRequestsChannel(requests), batchOptions, opts...) {
+		results = append(results, result)
+	}
+	return results
+}
+
+// InvokeBatchStream is the same as InvokeBatch but reads requests from channel. Results are sent in order of requests and channel of results is closed after requests channel is closed.
+// Results must be read, otherwise reading of requests is suspended
+func (c *UserClient) InvokeBatchStream(requests <-chan BatchRequest, batchOptions BatchOptions, opts ...Option) <-chan BatchResult {
+	return c.InvokeBatchStreamContext(context.Background(), requests, batchOptions, opts...)
+}
+
+// InvokeBatchStreamContext is the same as InvokeBatchStream but transactions are bound to ctx. If ctx is done remaining transactions fail with ctx.Err()
+func (c *UserClient) InvokeBatchStreamContext(ctx context.Context, requests <-chan BatchRequest, batchOptions BatchOptions, opts ...Option) <-chan BatchResult {
+	// pending keeps results of running transactions in order of requests. Its capacity with result awaited by collector limits parallelism
+	pending := make(chan chan BatchResult, batchOptions.parallelism()-1)
+	results := make(chan BatchResult)
+	go func() {
+		defer close(pending)
+		limiter := newRateLimiter(batchOptions.RateLimit)
+		index := 0
+		for request := range requests {
+			result := make(chan BatchResult, 1)
+			pending <- result
+			if err := limiter.wait(ctx); err != nil {
+				result <- BatchResult{Index: index, Err: err}
+				index++
+				continue
+			}
+			go func(index int, request BatchRequest) {
+				receipt, err := c.InvokeWithReceiptContext(ctx, request.ChaincodeID, request.FunctionName, request.Args, opts...)
+				result <- BatchResult{Index: index, Receipt: receipt, Err: err}
+			}(index, request)
+			index++
+		}
+	}()
+	go func() {
+		defer close(results)
+		for result := range pending {
+			results <- <-result
+		}
+	}()
+	return results
+}
+
+// InvokeBatch invokes transactions concurrently according to batchOptions and returns results in order of requests.
+// Failed transactions do not abort batch, error of every transaction is returned in its result
+func (c *ChaincodeClient) InvokeBatch(requests []BatchRequest, batchOptions BatchOptions, opts ...Option) []BatchResult {
+	return c.InvokeBatchContext(context.Background(), requests, batchOptions, opts...)
+}
+
+// InvokeBatchContext is the same as InvokeBatch but transactions are bound to ctx. If ctx is done remaining transactions fail with ctx.Err()
+func (c *ChaincodeClient) InvokeBatchContext(ctx context.Context, requests []BatchRequest, batchOptions BatchOptions, opts ...Option) []BatchResult {
+	return c.userClient.InvokeBatchContext(ctx, c.batchRequests(requests), batchOptions, opts...)
+}
+
+// InvokeBatchStream is the same as InvokeBatch but reads requests from channel. Results are sent in order of requests and channel of results is closed after requests channel is closed.
+// Results must be read, otherwise reading of requests is suspended
+func (c *ChaincodeClient) InvokeBatchStream(requests <-chan BatchRequest, batchOptions BatchOptions, opts ...Option) <-chan BatchResult {
+	return c.InvokeBatchStreamContext(context.Background(), requests, batchOptions, opts...)
+}
+
+// InvokeBatchStreamContext is the same as InvokeBatchStream but transactions are bound to ctx. If ctx is done remaining transactions fail with ctx.Err()
+func (c *ChaincodeClient) InvokeBatchStreamContext(ctx context.Context, requests <-chan BatchRequest, batchOptions BatchOptions, opts ...Option) <-chan BatchResult {
+	chaincodeRequests := make(chan BatchRequest)
+	go func() {
+		defer close(chaincodeRequests)
+		for request := range requests {
+			request.ChaincodeID = c.chaincodeID
+			chaincodeRequests <- request
+		}
+	}()
+	return c.userClient.InvokeBatchStreamContext(ctx, chaincodeRequests, batchOptions, opts...)
+}
+
+// batchRequests returns copy of requests addressed to chaincode of client
+func (c *ChaincodeClient) batchRequests(requests []BatchRequest) []BatchRequest {
+	chaincodeRequests := make([]BatchRequest, len(requests))
+	for i, request := range requests {
+		request.ChaincodeID = c.chaincodeID
+		chaincodeRequests[i] = request
+	}
+	return chaincodeRequests
+}
+
+// batchRequestsChannel returns closed channel filled with requests
+func batchRequestsChannel(requests []BatchRequest) <-chan BatchRequest {
+	queue := make(chan BatchRequest, len(requests))
+	for _, request := range requests {
+		queue <- request
+	}
+	close(queue)
+	return queue
+}
@@ -0,0 +1,255 @@
+package fabclient
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/event"
+	sdkcontext "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/seek"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+)
+
+// BlockListenerMode defines kind of blocks delivered by BlockListener
+type BlockListenerMode int
+
+const (
+	// FilteredBlocks mode delivers filtered blocks which contain only transaction ids, validation codes and chaincode event names
+	FilteredBlocks BlockListenerMode = iota
+	// FullBlocks mode delivers full blocks. User must be allowed to receive full blocks by channel policy
+	FullBlocks
+)
+
+// StartPosition defines block from which BlockListener starts if there is no checkpoint
+type StartPosition struct {
+	seekType    seek.Type
+	blockNumber uint64
+}
+
+var (
+	// StartFromOldest starts listening from genesis block
+	StartFromOldest = StartPosition{seekType: seek.Oldest}
+	// StartFromNewest starts listening from last committed block
+	StartFromNewest = StartPosition{seekType: seek.Newest}
+)
+
+// StartFromBlock starts listening from block with passed number
+func StartFromBlock(blockNumber uint64) StartPosition {
+	return StartPosition{seekType: seek.FromBlock, blockNumber: blockNumber}
+}
+
+// BlockEvent is block delivered by BlockListener. Block or FilteredBlock is set depending on listener mode.
+// Ack must be called when block is processed
+type BlockEvent struct {
+	Number        uint64
+	Block         *common.Block
+	FilteredBlock *pb.FilteredBlock
+	listener      *BlockListener
+}
+
+// Ack acknowledges that block is processed so listener resumes from next block after restart
+func (e *BlockEvent) Ack() error {
+	return e.listener.ack(e.Number)
+}
+
+// BlockListener delivers blocks committed to channel and resumes from last acknowledged block after restart
+type BlockListener struct {
+	channelID       string
+	fabricClient    *FabricClient
+	channelProvider sdkcontext.ChannelProvider
+	mode            BlockListenerMode
+	start           StartPosition
+	checkpointer    Checkpointer
+	lock            sync.Mutex
+	eventClient     *event.Client
+	registration    fab.Registration
+	// done is closed when listener is stopped. It is nil if listener is not started
+	done      chan struct{}
+	nextBlock uint64
+	// nextAck is the lowest block which is not acknowledged yet. It is known after first block is delivered or if checkpoint is found
+	nextAck      uint64
+	nextAckKnown bool
+	// acks contains acknowledged blocks following nextAck which are not saved to checkpointer until gap below them is acknowledged
+	acks map[uint64]bool
+}
+
+// CreateBlockListener creates new Block Listener. If checkpointer contains acknowledged block listener starts from next block, otherwise from start
+func (c *FabricClient) CreateBlockListener(channelID string, name string, organization string, mode BlockListenerMode, start StartPosition, checkpointer Checkpointer) (*BlockListener, error) {
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
+	if checkpointer == nil {
+		return nil, fmt.Errorf("Failed to create block listener for channel %s: checkpointer is nil", channelID)
+	}
+	lastAcked, acked, err := checkpointer.LastBlock()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read checkpoint of block listener for channel %s.\n Error: %w", channelID, err)
+	}
+	blockListener := &BlockListener{
+		channelID:       channelID,
+		fabricClient:    c,
+		channelProvider: c.sdk.ChannelContext(channelID, fabsdk.WithUser(name), fabsdk.WithOrg(organization)),
+		mode:            mode,
+		start:           start,
+		checkpointer:    checkpointer,
+		acks:            make(map[uint64]bool),
+	}
+	if acked {
+		blockListener.start = StartFromBlock(lastAcked + 1)
+		blockListener.nextAck = lastAcked + 1
+		blockListener.nextAckKnown = true
+	}
+	if blockListener.start.seekType == seek.FromBlock {
+		blockListener.nextBlock = blockListener.start.blockNumber
+	}
+	logger.Debugf("Block listener for channelID: %s, user: %s and organization: %s created", channelID, name, organization)
+	return blockListener, nil
+}
+
+// Start connects to event service and returns channel of blocks. Channel is closed when listener is stopped.
+// Stopped listener can be started again, it resumes from first block which is not acknowledged
+func (l *BlockListener) Start() (<-chan *BlockEvent, error) {
+	if err := l.fabricClient.checkOpen(); err != nil {
+		return nil, err
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.done != nil {
+		return nil, fmt.Errorf("Failed to start block listener for channel %s: listener is already started", l.channelID)
+	}
+	start := l.start
+	if l.nextAckKnown {
+		// blocks which are delivered but not acknowledged before stop are delivered again
+		start = StartFromBlock(l.nextAck)
+		l.nextBlock = l.nextAck
+	}
+	opts := []event.ClientOption{event.WithSeekType(start.seekType)}
+	if start.seekType == seek.FromBlock {
+		opts = append(opts, event.WithBlockNum(start.blockNumber))
+	}
+	if l.mode == FullBlocks {
+		opts = append(opts, event.WithBlockEvents())
+	}
+	eventClient, err := event.New(l.channelProvider, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create event client for channel %s.\n Error: %w", l.channelID, err)
+	}
+	done := make(chan struct{})
+	blocks := make(chan *BlockEvent)
+	var registration fab.Registration
+
+	switch l.mode {
+	case FullBlocks:
+		var blockEvents <-chan *fab.BlockEvent
+		registration, blockEvents, err = eventClient.RegisterBlockEvent()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to register for block events of channel %s.\n Error: %w", l.channelID, err)
+		}
+		go func() {
+			defer close(blocks)
+			for blockEvent := range blockEvents {
+				if !l.deliver(done, blocks, &BlockEvent{Number: blockEvent.Block.Header.Number, Block: blockEvent.Block, listener: l}) {
+					return
+				}
+			}
+		}()
+	default:
+		var blockEvents <-chan *fab.FilteredBlockEvent
+		registration, blockEvents, err = eventClient.RegisterFilteredBlockEvent()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to register for filtered block events of channel %s.\n Error: %w", l.channelID, err)
+		}
+		go func() {
+			defer close(blocks)
+			for blockEvent := range blockEvents {
+				if !l.deliver(done, blocks, &BlockEvent{Number: blockEvent.FilteredBlock.Number, FilteredBlock: blockEvent.FilteredBlock, listener: l}) {
+					return
+				}
+			}
+		}()
+	}
+	if err = l.fabricClient.track(l, l.Stop); err != nil {
+		close(done)
+		eventClient.Unregister(registration)
+		return nil, err
+	}
+	l.eventClient = eventClient
+	l.registration = registration
+	l.done = done
+	logger.Debugf("Block listener for channel %s started", l.channelID)
+	return blocks, nil
+}
+
+// Stop unregisters listener from event service and closes channel of blocks. Stop of stopped listener does nothing.
+// Started listener is stopped when Fabric Client is closed
+func (l *BlockListener) Stop() {
+	l.lock.Lock()
+	if l.done == nil {
+		l.lock.Unlock()
+		return
+	}
+	// done is closed under lock so blocks of stopped run are not delivered after listener is started again
+	close(l.done)
+	eventClient, registration := l.eventClient, l.registration
+	l.eventClient, l.registration, l.done = nil, nil, nil
+	l.lock.Unlock()
+	l.fabricClient.untrack(l)
+	eventClient.Unregister(registration)
+	logger.Debugf("Block listener for channel %s stopped", l.channelID)
+}
+
+// deliver skips blocks which were already delivered, e.g. after reconnection to other peer. It returns false if listener is stopped
+func (l *BlockListener) deliver(done <-chan struct{}, blocks chan<- *BlockEvent, blockEvent *BlockEvent) bool {
+	l.lock.Lock()
+	select {
+	case <-done:
+		l.lock.Unlock()
+		return false
+	default:
+	}
+	if blockEvent.Number < l.nextBlock {
+		l.lock.Unlock()
+		logger.Debugf("Block %d of channel %s is already delivered, skipping", blockEvent.Number, l.channelID)
+		return true
+	}
+	l.nextBlock = blockEvent.Number + 1
+	if !l.nextAckKnown {
+		l.nextAck = blockEvent.Number
+		l.nextAckKnown = true
+	}
+	l.lock.Unlock()
+	select {
+	case blocks <- blockEvent:
+		return true
+	case <-done:
+		return false
+	}
+}
+
+// ack saves the highest block which has no unacknowledged blocks below it, so blocks acknowledged out of order are not skipped after restart
+func (l *BlockListener) ack(blockNumber uint64) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if blockNumber < l.nextAck || l.acks[blockNumber] {
+		return nil
+	}
+	l.acks[blockNumber] = true
+	next := l.nextAck
+	for l.acks[next] {
+		next++
+	}
+	if next == l.nextAck {
+		return nil
+	}
+	if err := l.checkpointer.Save(next - 1); err != nil {
+		delete(l.acks, blockNumber)
+		return fmt.Errorf("Failed to save checkpoint %d of block listener for channel %s.\n Error: %w", next-1, l.channelID, err)
+	}
+	for ; l.nextAck < next; l.nextAck++ {
+		delete(l.acks, l.nextAck)
+	}
+	return nil
+}
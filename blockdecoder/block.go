@@ -0,0 +1,108 @@
+// Package blockdecoder decodes fabric blocks into plain Go structures which can be serialized to JSON
+package blockdecoder
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Block is decoded fabric block
+type Block struct {
+	Number       uint64         `json:"number"`
+	PreviousHash string         `json:"previous_hash"`
+	DataHash     string         `json:"data_hash"`
+	Transactions []*Transaction `json:"transactions"`
+}
+
+// Transaction is decoded envelope of block. Actions are set for endorser transactions, Config for config transactions
+type Transaction struct {
+	TxID           string    `json:"tx_id"`
+	Type           string    `json:"type"`
+	ChannelID      string    `json:"channel_id"`
+	CreatorMSPID   string    `json:"creator_msp_id"`
+	Timestamp      time.Time `json:"timestamp"`
+	ValidationCode string    `json:"validation_code"`
+	Actions        []*Action `json:"actions,omitempty"`
+	Config         *Config   `json:"config,omitempty"`
+}
+
+// Action is chaincode invocation within endorser transaction. Function is converted to string, Args are kept as bytes and are base64 encoded in JSON
+type Action struct {
+	ChaincodeName    string            `json:"chaincode_name"`
+	ChaincodeVersion string            `json:"chaincode_version"`
+	Function         string            `json:"function"`
+	Args             [][]byte          `json:"args"`
+	Response         *Response         `json:"response"`
+	ReadWriteSets    []*NsReadWriteSet `json:"read_write_sets"`
+	Event            *ChaincodeEvent   `json:"event,omitempty"`
+	Endorsements     []*Endorsement    `json:"endorsements"`
+}
+
+// Response is chaincode response returned during simulation. Payload is base64 encoded in JSON
+type Response struct {
+	Status  int32  `json:"status"`
+	Message string `json:"message"`
+	Payload []byte `json:"payload"`
+}
+
+// NsReadWriteSet contains reads and writes of one namespace (chaincode)
+type NsReadWriteSet struct {
+	Namespace   string   `json:"namespace"`
+	Reads       []*Read  `json:"reads"`
+	Writes      []*Write `json:"writes"`
+	Collections []string `json:"collections,omitempty"`
+}
+
+// Read is key read during simulation with version of key at that time
+type Read struct {
+	Key     string   `json:"key"`
+	Version *Version `json:"version,omitempty"`
+}
+
+// Version is height of transaction which last wrote key
+type Version struct {
+	BlockNumber uint64 `json:"block_number"`
+	TxNumber    uint64 `json:"tx_number"`
+}
+
+// Write is key written during simulation. Value is base64 encoded in JSON
+type Write struct {
+	Key      string `json:"key"`
+	IsDelete bool   `json:"is_delete"`
+	Value    []byte `json:"value"`
+}
+
+// ChaincodeEvent is event set by chaincode during simulation. Payload is base64 encoded in JSON
+type ChaincodeEvent struct {
+	ChaincodeID string `json:"chaincode_id"`
+	TxID        string `json:"tx_id"`
+	EventName   string `json:"event_name"`
+	Payload     []byte `json:"payload"`
+}
+
+// Endorsement is signature of peer which endorsed transaction
+type Endorsement struct {
+	MSPID       string `json:"msp_id"`
+	Certificate string `json:"certificate"`
+	Signature   string `json:"signature"`
+}
+
+// Config is decoded channel configuration of config transaction
+type Config struct {
+	Sequence     uint64       `json:"sequence"`
+	ChannelGroup *ConfigGroup `json:"channel_group"`
+}
+
+// ConfigGroup is node of channel configuration tree. Known values are decoded to JSON of their protobuf messages, unknown are base64 encoded
+type ConfigGroup struct {
+	Version   uint64                     `json:"version"`
+	ModPolicy string                     `json:"mod_policy"`
+	Groups    map[string]*ConfigGroup    `json:"groups,omitempty"`
+	Values    map[string]json.RawMessage `json:"values,omitempty"`
+	Policies  map[string]string          `json:"policies,omitempty"`
+}
+
+// JSON serializes block to indented JSON
+func (b *Block) JSON() ([]byte, error) {
+	return json.MarshalIndent(b, "", "  ")
+}
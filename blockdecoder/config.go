@@ -0,0 +1,138 @@
+package blockdecoder
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// configValues maps keys of well known config values to constructors of their protobuf messages
+var configValues = map[string]func() proto.Message{
+	"HashingAlgorithm":          func() proto.Message { return &common.HashingAlgorithm{} },
+	"BlockDataHashingStructure": func() proto.Message { return &common.BlockDataHashingStructure{} },
+	"OrdererAddresses":          func() proto.Message { return &common.OrdererAddresses{} },
+	"Consortium":                func() proto.Message { return &common.Consortium{} },
+	"Capabilities":              func() proto.Message { return &common.Capabilities{} },
+	"BatchSize":                 func() proto.Message { return &orderer.BatchSize{} },
+	"BatchTimeout":              func() proto.Message { return &orderer.BatchTimeout{} },
+	"ConsensusType":             func() proto.Message { return &orderer.ConsensusType{} },
+	"ChannelRestrictions":       func() proto.Message { return &orderer.ChannelRestrictions{} },
+	"AnchorPeers":               func() proto.Message { return &pb.AnchorPeers{} },
+	"ACLs":                      func() proto.Message { return &pb.ACLs{} },
+}
+
+// DecodeConfig decodes channel configuration, e.g. from last config block
+func DecodeConfig(config *common.Config) (*Config, error) {
+	if config == nil {
+		return nil, fmt.Errorf("Failed to decode config: config is nil")
+	}
+	channelGroup, err := decodeConfigGroup(config.ChannelGroup)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{Sequence: config.Sequence, ChannelGroup: channelGroup}, nil
+}
+
+func decodeConfigEnvelope(data []byte) (*Config, error) {
+	configEnvelope := &common.ConfigEnvelope{}
+	if err := proto.Unmarshal(data, configEnvelope); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal config envelope.\n Error: %w", err)
+	}
+	return DecodeConfig(configEnvelope.Config)
+}
+
+func decodeConfigGroup(group *common.ConfigGroup) (*ConfigGroup, error) {
+	if group == nil {
+		return nil, nil
+	}
+	decoded := &ConfigGroup{
+		Version:   group.Version,
+		ModPolicy: group.ModPolicy,
+		Groups:    map[string]*ConfigGroup{},
+		Values:    map[string]json.RawMessage{},
+		Policies:  map[string]string{},
+	}
+	for name, subgroup := range group.Groups {
+		decodedSubgroup, err := decodeConfigGroup(subgroup)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode config group %s.\n Error: %w", name, err)
+		}
+		decoded.Groups[name] = decodedSubgroup
+	}
+	for name, value := range group.Values {
+		decodedValue, err := decodeConfigValue(name, value.Value)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode config value %s.\n Error: %w", name, err)
+		}
+		decoded.Values[name] = decodedValue
+	}
+	for name, policy := range group.Policies {
+		decodedPolicy, err := decodePolicy(policy.Policy)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode config policy %s.\n Error: %w", name, err)
+		}
+		decoded.Policies[name] = decodedPolicy
+	}
+	return decoded, nil
+}
+
+func decodeConfigValue(name string, value []byte) (json.RawMessage, error) {
+	if name == "MSP" {
+		return decodeMSPConfig(value)
+	}
+	newMessage, ok := configValues[name]
+	if !ok {
+		return json.Marshal(value)
+	}
+	message := newMessage()
+	if err := proto.Unmarshal(value, message); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal %T.\n Error: %w", message, err)
+	}
+	return marshalMessage(message)
+}
+
+// decodeMSPConfig decodes nested fabric MSP configuration, other MSP types are left encoded
+func decodeMSPConfig(value []byte) (json.RawMessage, error) {
+	mspConfig := &mspproto.MSPConfig{}
+	if err := proto.Unmarshal(value, mspConfig); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal MSP config.\n Error: %w", err)
+	}
+	if mspConfig.Type != 0 {
+		return marshalMessage(mspConfig)
+	}
+	fabricMSPConfig := &mspproto.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricMSPConfig); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal fabric MSP config.\n Error: %w", err)
+	}
+	return marshalMessage(fabricMSPConfig)
+}
+
+func decodePolicy(policy *common.Policy) (string, error) {
+	if policy == nil {
+		return "", nil
+	}
+	policyType := common.Policy_PolicyType(policy.Type)
+	if policyType != common.Policy_IMPLICIT_META {
+		return policyType.String(), nil
+	}
+	implicitMetaPolicy := &common.ImplicitMetaPolicy{}
+	if err := proto.Unmarshal(policy.Value, implicitMetaPolicy); err != nil {
+		return "", fmt.Errorf("Failed to unmarshal implicit meta policy.\n Error: %w", err)
+	}
+	return fmt.Sprintf("%s %s %s", policyType, implicitMetaPolicy.Rule, implicitMetaPolicy.SubPolicy), nil
+}
+
+func marshalMessage(message proto.Message) (json.RawMessage, error) {
+	marshaler := jsonpb.Marshaler{OrigName: true}
+	value, err := marshaler.MarshalToString(message)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal %T to JSON.\n Error: %w", message, err)
+	}
+	return json.RawMessage(value), nil
+}
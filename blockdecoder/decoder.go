@@ -0,0 +1,233 @@
+package blockdecoder
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// Decode decodes fabric block
+func Decode(block *common.Block) (*Block, error) {
+	if block == nil || block.Header == nil || block.Data == nil {
+		return nil, fmt.Errorf("Failed to decode block: block, its header or data is nil")
+	}
+	decoded := &Block{
+		Number:       block.Header.Number,
+		PreviousHash: hex.EncodeToString(block.Header.PreviousHash),
+		DataHash:     hex.EncodeToString(block.Header.DataHash),
+	}
+	var validationCodes []byte
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		validationCodes = block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	}
+	for i, envelopeBytes := range block.Data.Data {
+		transaction, err := decodeEnvelope(envelopeBytes)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode transaction %d of block %d.\n Error: %w", i, block.Header.Number, err)
+		}
+		if i < len(validationCodes) {
+			transaction.ValidationCode = pb.TxValidationCode(validationCodes[i]).String()
+		}
+		decoded.Transactions = append(decoded.Transactions, transaction)
+	}
+	return decoded, nil
+}
+
+// DecodeEnvelope decodes transaction envelope, e.g. from ProcessedTransaction returned by ledger query
+func DecodeEnvelope(envelope *common.Envelope) (*Transaction, error) {
+	payload := &common.Payload{}
+	if err := proto.Unmarshal(envelope.Payload, payload); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal payload.\n Error: %w", err)
+	}
+	if payload.Header == nil {
+		return nil, fmt.Errorf("Failed to decode envelope: payload header is nil")
+	}
+	channelHeader := &common.ChannelHeader{}
+	if err := proto.Unmarshal(payload.Header.ChannelHeader, channelHeader); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal channel header.\n Error: %w", err)
+	}
+	signatureHeader := &common.SignatureHeader{}
+	if err := proto.Unmarshal(payload.Header.SignatureHeader, signatureHeader); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal signature header.\n Error: %w", err)
+	}
+	creator, err := decodeIdentity(signatureHeader.Creator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode creator.\n Error: %w", err)
+	}
+	transaction := &Transaction{
+		TxID:         channelHeader.TxId,
+		Type:         common.HeaderType(channelHeader.Type).String(),
+		ChannelID:    channelHeader.ChannelId,
+		CreatorMSPID: creator.Mspid,
+	}
+	if channelHeader.Timestamp != nil {
+		transaction.Timestamp, err = ptypes.Timestamp(channelHeader.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to convert timestamp of transaction %s.\n Error: %w", channelHeader.TxId, err)
+		}
+	}
+	switch common.HeaderType(channelHeader.Type) {
+	case common.HeaderType_ENDORSER_TRANSACTION:
+		transaction.Actions, err = decodeActions(payload.Data)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode actions of transaction %s.\n Error: %w", channelHeader.TxId, err)
+		}
+	case common.HeaderType_CONFIG:
+		transaction.Config, err = decodeConfigEnvelope(payload.Data)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode config of transaction %s.\n Error: %w", channelHeader.TxId, err)
+		}
+	}
+	return transaction, nil
+}
+
+func decodeEnvelope(envelopeBytes []byte) (*Transaction, error) {
+	envelope := &common.Envelope{}
+	if err := proto.Unmarshal(envelopeBytes, envelope); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal envelope.\n Error: %w", err)
+	}
+	return DecodeEnvelope(envelope)
+}
+
+func decodeIdentity(identityBytes []byte) (*mspproto.SerializedIdentity, error) {
+	identity := &mspproto.SerializedIdentity{}
+	if err := proto.Unmarshal(identityBytes, identity); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal serialized identity.\n Error: %w", err)
+	}
+	return identity, nil
+}
+
+func decodeActions(data []byte) ([]*Action, error) {
+	tx := &pb.Transaction{}
+	if err := proto.Unmarshal(data, tx); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal transaction.\n Error: %w", err)
+	}
+	var actions []*Action
+	for i, transactionAction := range tx.Actions {
+		action, err := decodeAction(transactionAction)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode action %d.\n Error: %w", i, err)
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+func decodeAction(transactionAction *pb.TransactionAction) (*Action, error) {
+	actionPayload := &pb.ChaincodeActionPayload{}
+	if err := proto.Unmarshal(transactionAction.Payload, actionPayload); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal chaincode action payload.\n Error: %w", err)
+	}
+	action := &Action{}
+	if err := decodeInvocation(actionPayload.ChaincodeProposalPayload, action); err != nil {
+		return nil, err
+	}
+	if actionPayload.Action == nil {
+		return action, nil
+	}
+	for _, endorsement := range actionPayload.Action.Endorsements {
+		endorser, err := decodeIdentity(endorsement.Endorser)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode endorser.\n Error: %w", err)
+		}
+		action.Endorsements = append(action.Endorsements, &Endorsement{
+			MSPID:       endorser.Mspid,
+			Certificate: string(endorser.IdBytes),
+			Signature:   hex.EncodeToString(endorsement.Signature),
+		})
+	}
+	responsePayload := &pb.ProposalResponsePayload{}
+	if err := proto.Unmarshal(actionPayload.Action.ProposalResponsePayload, responsePayload); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal proposal response payload.\n Error: %w", err)
+	}
+	chaincodeAction := &pb.ChaincodeAction{}
+	if err := proto.Unmarshal(responsePayload.Extension, chaincodeAction); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal chaincode action.\n Error: %w", err)
+	}
+	if chaincodeAction.Response != nil {
+		action.Response = &Response{
+			Status:  chaincodeAction.Response.Status,
+			Message: chaincodeAction.Response.Message,
+			Payload: chaincodeAction.Response.Payload,
+		}
+	}
+	if len(chaincodeAction.Events) > 0 {
+		event := &pb.ChaincodeEvent{}
+		if err := proto.Unmarshal(chaincodeAction.Events, event); err != nil {
+			return nil, fmt.Errorf("Failed to unmarshal chaincode event.\n Error: %w", err)
+		}
+		action.Event = &ChaincodeEvent{
+			ChaincodeID: event.ChaincodeId,
+			TxID:        event.TxId,
+			EventName:   event.EventName,
+			Payload:     event.Payload,
+		}
+	}
+	readWriteSets, err := decodeReadWriteSets(chaincodeAction.Results)
+	if err != nil {
+		return nil, err
+	}
+	action.ReadWriteSets = readWriteSets
+	return action, nil
+}
+
+func decodeInvocation(proposalPayloadBytes []byte, action *Action) error {
+	proposalPayload := &pb.ChaincodeProposalPayload{}
+	if err := proto.Unmarshal(proposalPayloadBytes, proposalPayload); err != nil {
+		return fmt.Errorf("Failed to unmarshal chaincode proposal payload.\n Error: %w", err)
+	}
+	invocationSpec := &pb.ChaincodeInvocationSpec{}
+	if err := proto.Unmarshal(proposalPayload.Input, invocationSpec); err != nil {
+		return fmt.Errorf("Failed to unmarshal chaincode invocation spec.\n Error: %w", err)
+	}
+	spec := invocationSpec.ChaincodeSpec
+	if spec == nil {
+		return nil
+	}
+	if spec.ChaincodeId != nil {
+		action.ChaincodeName = spec.ChaincodeId.Name
+		action.ChaincodeVersion = spec.ChaincodeId.Version
+	}
+	if spec.Input != nil && len(spec.Input.Args) > 0 {
+		action.Function = string(spec.Input.Args[0])
+		action.Args = spec.Input.Args[1:]
+	}
+	return nil
+}
+
+func decodeReadWriteSets(results []byte) ([]*NsReadWriteSet, error) {
+	txReadWriteSet := &rwset.TxReadWriteSet{}
+	if err := proto.Unmarshal(results, txReadWriteSet); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal read write set.\n Error: %w", err)
+	}
+	var readWriteSets []*NsReadWriteSet
+	for _, nsReadWriteSet := range txReadWriteSet.NsRwset {
+		kvReadWriteSet := &kvrwset.KVRWSet{}
+		if err := proto.Unmarshal(nsReadWriteSet.Rwset, kvReadWriteSet); err != nil {
+			return nil, fmt.Errorf("Failed to unmarshal read write set of namespace %s.\n Error: %w", nsReadWriteSet.Namespace, err)
+		}
+		readWriteSet := &NsReadWriteSet{Namespace: nsReadWriteSet.Namespace}
+		for _, kvRead := range kvReadWriteSet.Reads {
+			read := &Read{Key: kvRead.Key}
+			if kvRead.Version != nil {
+				read.Version = &Version{BlockNumber: kvRead.Version.BlockNum, TxNumber: kvRead.Version.TxNum}
+			}
+			readWriteSet.Reads = append(readWriteSet.Reads, read)
+		}
+		for _, kvWrite := range kvReadWriteSet.Writes {
+			readWriteSet.Writes = append(readWriteSet.Writes, &Write{Key: kvWrite.Key, IsDelete: kvWrite.IsDelete, Value: kvWrite.Value})
+		}
+		for _, collection := range nsReadWriteSet.CollectionHashedRwset {
+			readWriteSet.Collections = append(readWriteSet.Collections, collection.CollectionName)
+		}
+		readWriteSets = append(readWriteSets, readWriteSet)
+	}
+	return readWriteSets, nil
+}
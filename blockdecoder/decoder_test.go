@@ -0,0 +1,193 @@
+package blockdecoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// binary is not valid UTF-8, so it is corrupted if it is converted to string
+var binary = []byte{0xff, 0x00, 0xfe, 'a'}
+
+func marshal(t *testing.T, message proto.Message) []byte {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func identity(t *testing.T, mspID string) []byte {
+	return marshal(t, &mspproto.SerializedIdentity{Mspid: mspID, IdBytes: []byte("certificate of " + mspID)})
+}
+
+// block creates block with single envelope of given type and data
+func block(t *testing.T, headerType common.HeaderType, txID string, data []byte, validationCode pb.TxValidationCode) *common.Block {
+	payload := &common.Payload{
+		Header: &common.Header{
+			ChannelHeader:   marshal(t, &common.ChannelHeader{Type: int32(headerType), TxId: txID, ChannelId: "channel", Timestamp: ptypes.TimestampNow()}),
+			SignatureHeader: marshal(t, &common.SignatureHeader{Creator: identity(t, "Org1MSP")}),
+		},
+		Data: data,
+	}
+	envelope := &common.Envelope{Payload: marshal(t, payload)}
+	metadata := make([][]byte, len(common.BlockMetadataIndex_name))
+	metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{byte(validationCode)}
+	return &common.Block{
+		Header:   &common.BlockHeader{Number: 5, PreviousHash: []byte{1, 2}, DataHash: []byte{3, 4}},
+		Data:     &common.BlockData{Data: [][]byte{marshal(t, envelope)}},
+		Metadata: &common.BlockMetadata{Metadata: metadata},
+	}
+}
+
+func endorserTransaction(t *testing.T) []byte {
+	invocation := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{
+		ChaincodeId: &pb.ChaincodeID{Name: "cc", Version: "1.0"},
+		Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte("put"), []byte("key"), binary}},
+	}}
+	kvSet := &kvrwset.KVRWSet{
+		Reads:  []*kvrwset.KVRead{{Key: "key", Version: &kvrwset.Version{BlockNum: 3, TxNum: 1}}},
+		Writes: []*kvrwset.KVWrite{{Key: "key", Value: binary}},
+	}
+	results := &rwset.TxReadWriteSet{NsRwset: []*rwset.NsReadWriteSet{{
+		Namespace:             "cc",
+		Rwset:                 marshal(t, kvSet),
+		CollectionHashedRwset: []*rwset.CollectionHashedReadWriteSet{{CollectionName: "private"}},
+	}}}
+	chaincodeAction := &pb.ChaincodeAction{
+		Results:  marshal(t, results),
+		Events:   marshal(t, &pb.ChaincodeEvent{ChaincodeId: "cc", TxId: "tx", EventName: "stored", Payload: binary}),
+		Response: &pb.Response{Status: 200, Message: "OK", Payload: binary},
+	}
+	actionPayload := &pb.ChaincodeActionPayload{
+		ChaincodeProposalPayload: marshal(t, &pb.ChaincodeProposalPayload{Input: marshal(t, invocation)}),
+		Action: &pb.ChaincodeEndorsedAction{
+			ProposalResponsePayload: marshal(t, &pb.ProposalResponsePayload{Extension: marshal(t, chaincodeAction)}),
+			Endorsements:            []*pb.Endorsement{{Endorser: identity(t, "Org2MSP"), Signature: []byte{0xab}}},
+		},
+	}
+	return marshal(t, &pb.Transaction{Actions: []*pb.TransactionAction{{Payload: marshal(t, actionPayload)}}})
+}
+
+func TestDecodeEndorserTransaction(t *testing.T) {
+	decoded, err := Decode(block(t, common.HeaderType_ENDORSER_TRANSACTION, "tx", endorserTransaction(t), pb.TxValidationCode_VALID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Number != 5 || decoded.PreviousHash != "0102" || decoded.DataHash != "0304" || len(decoded.Transactions) != 1 {
+		t.Fatalf("unexpected block %+v", decoded)
+	}
+	transaction := decoded.Transactions[0]
+	if transaction.TxID != "tx" || transaction.Type != "ENDORSER_TRANSACTION" || transaction.CreatorMSPID != "Org1MSP" || transaction.ValidationCode != "VALID" {
+		t.Fatalf("unexpected transaction %+v", transaction)
+	}
+	if len(transaction.Actions) != 1 {
+		t.Fatalf("transaction has %d actions, expected 1", len(transaction.Actions))
+	}
+	action := transaction.Actions[0]
+	if action.ChaincodeName != "cc" || action.ChaincodeVersion != "1.0" || action.Function != "put" || len(action.Args) != 2 || !bytes.Equal(action.Args[1], binary) {
+		t.Fatalf("unexpected invocation %+v", action)
+	}
+	if action.Response.Status != 200 || !bytes.Equal(action.Response.Payload, binary) {
+		t.Fatalf("unexpected response %+v", action.Response)
+	}
+	if action.Event.EventName != "stored" || !bytes.Equal(action.Event.Payload, binary) {
+		t.Fatalf("unexpected event %+v", action.Event)
+	}
+	if len(action.Endorsements) != 1 || action.Endorsements[0].MSPID != "Org2MSP" || action.Endorsements[0].Signature != "ab" {
+		t.Fatalf("unexpected endorsements %+v", action.Endorsements)
+	}
+	readWriteSet := action.ReadWriteSets[0]
+	if readWriteSet.Namespace != "cc" || readWriteSet.Reads[0].Version.BlockNumber != 3 || !bytes.Equal(readWriteSet.Writes[0].Value, binary) || readWriteSet.Collections[0] != "private" {
+		t.Fatalf("unexpected read write set %+v", readWriteSet)
+	}
+	// binary data survives JSON serialization
+	encoded, err := decoded.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := &Block{}
+	if err = json.Unmarshal(encoded, restored); err != nil {
+		t.Fatal(err)
+	}
+	restoredAction := restored.Transactions[0].Actions[0]
+	if !bytes.Equal(restoredAction.Args[1], binary) || !bytes.Equal(restoredAction.ReadWriteSets[0].Writes[0].Value, binary) || !bytes.Equal(restoredAction.Response.Payload, binary) {
+		t.Fatal("binary data is corrupted by JSON serialization")
+	}
+}
+
+func TestDecodeConfigTransaction(t *testing.T) {
+	fabricMSPConfig := &mspproto.FabricMSPConfig{Name: "Org1MSP"}
+	config := &common.Config{
+		Sequence: 2,
+		ChannelGroup: &common.ConfigGroup{
+			Groups: map[string]*common.ConfigGroup{
+				"Orderer": {
+					Values: map[string]*common.ConfigValue{
+						"BatchSize": {Value: marshal(t, &orderer.BatchSize{MaxMessageCount: 10})},
+						"Unknown":   {Value: binary},
+					},
+				},
+				"Application": {
+					Groups: map[string]*common.ConfigGroup{
+						"Org1MSP": {Values: map[string]*common.ConfigValue{
+							"MSP": {Value: marshal(t, &mspproto.MSPConfig{Config: marshal(t, fabricMSPConfig)})},
+						}},
+					},
+					Policies: map[string]*common.ConfigPolicy{
+						"Admins": {Policy: &common.Policy{
+							Type:  int32(common.Policy_IMPLICIT_META),
+							Value: marshal(t, &common.ImplicitMetaPolicy{Rule: common.ImplicitMetaPolicy_MAJORITY, SubPolicy: "Admins"}),
+						}},
+					},
+				},
+			},
+		},
+	}
+	data := marshal(t, &common.ConfigEnvelope{Config: config})
+	decoded, err := Decode(block(t, common.HeaderType_CONFIG, "", data, pb.TxValidationCode_VALID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decodedConfig := decoded.Transactions[0].Config
+	if decodedConfig == nil || decodedConfig.Sequence != 2 {
+		t.Fatalf("unexpected config %+v", decodedConfig)
+	}
+	tests := []struct {
+		name     string
+		value    json.RawMessage
+		contains string
+	}{
+		{name: "known value", value: decodedConfig.ChannelGroup.Groups["Orderer"].Values["BatchSize"], contains: `"max_message_count":10`},
+		{name: "unknown value", value: decodedConfig.ChannelGroup.Groups["Orderer"].Values["Unknown"], contains: `"/wD+YQ=="`},
+		{name: "MSP value", value: decodedConfig.ChannelGroup.Groups["Application"].Groups["Org1MSP"].Values["MSP"], contains: `"name":"Org1MSP"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !strings.Contains(string(test.value), test.contains) {
+				t.Fatalf("value %s does not contain %s", test.value, test.contains)
+			}
+		})
+	}
+	if policy := decodedConfig.ChannelGroup.Groups["Application"].Policies["Admins"]; policy != "IMPLICIT_META MAJORITY Admins" {
+		t.Fatalf("unexpected policy %s", policy)
+	}
+}
+
+func TestDecodeRejectsIncompleteBlock(t *testing.T) {
+	for _, incomplete := range []*common.Block{nil, {}, {Header: &common.BlockHeader{}}} {
+		if _, err := Decode(incomplete); err == nil {
+			t.Fatalf("error is expected for block %+v", incomplete)
+		}
+	}
+}
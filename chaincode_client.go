@@ -1,6 +1,8 @@
 package fabclient
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
@@ -13,37 +15,115 @@ type ChaincodeClient struct {
 }
 
 // CreateChaincodeClient is the same as  (c *FabricClient) CreateChaincodeClient(channelID string, name string, organization string) but it does not reuse Fabric Client
-func CreateChaincodeClient(configPath string, ordererHost string, channelID string, chaincodeID string, name string, organization string) (*ChaincodeClient, error) {
+func CreateChaincodeClient(configPath string, ordererHost string, channelID string, chaincodeID string, name string, organization string, opts ...Option) (*ChaincodeClient, error) {
 	fabricClient, err := CreateFabricClient(configPath, ordererHost)
 	if err != nil {
 		return nil, err
 	}
-	return fabricClient.CreateChaincodeClient(channelID, chaincodeID, name, organization)
+	return fabricClient.CreateChaincodeClient(channelID, chaincodeID, name, organization, opts...)
 }
 
-// Invoke triggers invokation of transaction
-func (c *ChaincodeClient) Invoke(functionName string, args [][]byte) ([]byte, error) {
-	resp, err := c.userClient.Invoke(c.chaincodeID, functionName, args)
+// withOptions returns copy of client which shares connections but has opts applied. Client itself is returned if there are no opts
+func (c *ChaincodeClient) withOptions(opts []Option) *ChaincodeClient {
+	if len(opts) == 0 {
+		return c
+	}
+	return &ChaincodeClient{chaincodeID: c.chaincodeID, userClient: c.userClient.withOptions(opts)}
+}
+
+// requestError returns error of request to chaincode. ctx.Err() is returned if ctx is done and ErrClosed is returned as is
+func (c *ChaincodeClient) requestError(ctx context.Context, action string, functionName string, args [][]byte, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if errors.Is(err, ErrClosed) {
+		return err
+	}
+	return fmt.Errorf("Failed to %s chaincode %s with function %s and arguments %v.\n Error: %w", action, c.chaincodeID, functionName, args, err)
+}
+
+// Invoke triggers invokation of transaction. opts override options of client for this request
+func (c *ChaincodeClient) Invoke(functionName string, args [][]byte, opts ...Option) ([]byte, error) {
+	return c.InvokeContext(context.Background(), functionName, args, opts...)
+}
+
+// InvokeContext is the same as Invoke but request is bound to ctx
+func (c *ChaincodeClient) InvokeContext(ctx context.Context, functionName string, args [][]byte, opts ...Option) ([]byte, error) {
+	resp, err := c.userClient.InvokeContext(ctx, c.chaincodeID, functionName, args, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to invoke chaincode %s with funactions %s and arguments %v.\n Error: %v", c.chaincodeID, functionName, args, err)
+		return nil, c.requestError(ctx, "invoke", functionName, args, err)
 	}
 	logger.Debugf("Response on invoke chaincode: %s\n", resp)
 	return resp, nil
 }
 
+// InvokeWithReceipt is the same as Invoke but returns Receipt with transaction id, validation code, endorsers and event of transaction
+func (c *ChaincodeClient) InvokeWithReceipt(functionName string, args [][]byte, opts ...Option) (*Receipt, error) {
+	return c.InvokeWithReceiptContext(context.Background(), functionName, args, opts...)
+}
+
+// InvokeWithReceiptContext is the same as InvokeWithReceipt but request is bound to ctx
+func (c *ChaincodeClient) InvokeWithReceiptContext(ctx context.Context, functionName string, args [][]byte, opts ...Option) (*Receipt, error) {
+	receipt, err := c.userClient.InvokeWithReceiptContext(ctx, c.chaincodeID, functionName, args, opts...)
+	if err != nil {
+		return nil, c.requestError(ctx, "invoke", functionName, args, err)
+	}
+	return receipt, nil
+}
+
 // Query is the same as Invoke but without sending transaction to orderer so tx does not added to blockchain history. It is used for querying data
-func (c *ChaincodeClient) Query(functionName string, args [][]byte) ([]byte, error) {
-	resp, err := c.userClient.Query(c.chaincodeID, functionName, args)
+func (c *ChaincodeClient) Query(functionName string, args [][]byte, opts ...Option) ([]byte, error) {
+	return c.QueryContext(context.Background(), functionName, args, opts...)
+}
+
+// QueryContext is the same as Query but request is bound to ctx
+func (c *ChaincodeClient) QueryContext(ctx context.Context, functionName string, args [][]byte, opts ...Option) ([]byte, error) {
+	resp, err := c.userClient.QueryContext(ctx, c.chaincodeID, functionName, args, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to query chaincode %s with funactions %s and arguments %v.\n Error: %v", c.chaincodeID, functionName, args, err)
+		return nil, c.requestError(ctx, "query", functionName, args, err)
+	}
+	logger.Debugf("Response on query chaincode: %s\n", resp)
+	return resp, nil
+}
+
+// InvokeWithTransient is the same as Invoke but passes transient map to chaincode. Transient data is not stored in transaction, so it is used for private data and secret inputs
+func (c *ChaincodeClient) InvokeWithTransient(functionName string, args [][]byte, transient map[string][]byte, opts ...Option) ([]byte, error) {
+	return c.InvokeWithTransientContext(context.Background(), functionName, args, transient, opts...)
+}
+
+// InvokeWithTransientContext is the same as InvokeWithTransient but request is bound to ctx
+func (c *ChaincodeClient) InvokeWithTransientContext(ctx context.Context, functionName string, args [][]byte, transient map[string][]byte, opts ...Option) ([]byte, error) {
+	resp, err := c.userClient.InvokeWithTransientContext(ctx, c.chaincodeID, functionName, args, transient, opts...)
+	if err != nil {
+		return nil, c.requestError(ctx, "invoke", functionName, args, err)
+	}
+	logger.Debugf("Response on invoke chaincode: %s\n", resp)
+	return resp, nil
+}
+
+// QueryWithTransient is the same as Query but passes transient map to chaincode
+func (c *ChaincodeClient) QueryWithTransient(functionName string, args [][]byte, transient map[string][]byte, opts ...Option) ([]byte, error) {
+	return c.QueryWithTransientContext(context.Background(), functionName, args, transient, opts...)
+}
+
+// QueryWithTransientContext is the same as QueryWithTransient but request is bound to ctx
+func (c *ChaincodeClient) QueryWithTransientContext(ctx context.Context, functionName string, args [][]byte, transient map[string][]byte, opts ...Option) ([]byte, error) {
+	resp, err := c.userClient.QueryWithTransientContext(ctx, c.chaincodeID, functionName, args, transient, opts...)
+	if err != nil {
+		return nil, c.requestError(ctx, "query", functionName, args, err)
 	}
 	logger.Debugf("Response on query chaincode: %s\n", resp)
 	return resp, nil
 }
 
 // QueryInt is the same as Query but converts result to integer
-func (c *ChaincodeClient) QueryInt(functionName string, args [][]byte) (int, error) {
-	resp, err := c.userClient.QueryInt(c.chaincodeID, functionName, args)
+func (c *ChaincodeClient) QueryInt(functionName string, args [][]byte, opts ...Option) (int, error) {
+	return c.QueryIntContext(context.Background(), functionName, args, opts...)
+}
+
+// QueryIntContext is the same as QueryInt but request is bound to ctx
+func (c *ChaincodeClient) QueryIntContext(ctx context.Context, functionName string, args [][]byte, opts ...Option) (int, error) {
+	resp, err := c.userClient.QueryIntContext(ctx, c.chaincodeID, functionName, args, opts...)
 	if err != nil {
 		return 0, err
 	}
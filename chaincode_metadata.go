@@ -0,0 +1,242 @@
+package fabclient
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// couchDBIndexPath matches index files of chaincode state and of private data collections. Collection name is captured
+var couchDBIndexPath = regexp.MustCompile(`^META-INF/statedb/couchdb/(?:collections/([^/]+)/)?indexes/[^/]+\.json$`)
+
+// PackageContents lists what is packed into chaincode package
+type PackageContents struct {
+	SourceFiles   []string
+	MetadataFiles []string
+	Indexes       []CouchDBIndex
+}
+
+// CouchDBIndex is CouchDB index definition packed into chaincode package. Collection is empty for index of chaincode state
+type CouchDBIndex struct {
+	Path       string
+	Collection string
+	Name       string
+	DesignDoc  string
+	Fields     []string
+}
+
+// Contents lists source files, metadata files and CouchDB indexes of chaincode package
+func (p *ChaincodePackage) Contents() (*PackageContents, error) {
+	return listPackageContents(p.Code)
+}
+
+// ListChaincodePackage lists source files, metadata files and CouchDB indexes of chaincode package file created by PackageChaincode
+func ListChaincodePackage(packagePath string) (*PackageContents, error) {
+	chaincodePackage, err := ReadChaincodePackage(packagePath)
+	if err != nil {
+		return nil, err
+	}
+	return chaincodePackage.Contents()
+}
+
+func listPackageContents(payload []byte) (*PackageContents, error) {
+	entries, err := readTarGz(payload)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read chaincode package.\n Error: %w", err)
+	}
+	contents := &PackageContents{}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.name, metadataPrefix) {
+			contents.SourceFiles = append(contents.SourceFiles, entry.name)
+			continue
+		}
+		contents.MetadataFiles = append(contents.MetadataFiles, entry.name)
+		if match := couchDBIndexPath.FindStringSubmatch(entry.name); match != nil {
+			index, err := parseCouchDBIndex(entry.name, entry.content)
+			if err != nil {
+				return nil, err
+			}
+			index.Collection = match[1]
+			contents.Indexes = append(contents.Indexes, *index)
+		}
+	}
+	return contents, nil
+}
+
+// addMetadataToPackage replaces metadata of chaincode package with content of metadataDir. metadataDir is META-INF directory itself
+func addMetadataToPackage(payload []byte, metadataDir string) ([]byte, error) {
+	metadataEntries, err := readMetadataDir(metadataDir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := readTarGz(payload)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read chaincode package.\n Error: %w", err)
+	}
+	sourceEntries := entries[:0]
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.name, metadataPrefix) {
+			sourceEntries = append(sourceEntries, entry)
+		}
+	}
+	return writeTarGz(append(sourceEntries, metadataEntries...))
+}
+
+// addMetadataToLifecyclePackage replaces metadata inside code.tar.gz of Fabric 2.x chaincode package
+func addMetadataToLifecyclePackage(payload []byte, metadataDir string) ([]byte, error) {
+	entries, err := readTarGz(payload)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read lifecycle chaincode package.\n Error: %w", err)
+	}
+	for i, entry := range entries {
+		if entry.name == "code.tar.gz" {
+			if entries[i].content, err = addMetadataToPackage(entry.content, metadataDir); err != nil {
+				return nil, err
+			}
+			return writeTarGz(entries)
+		}
+	}
+	return nil, fmt.Errorf("Lifecycle chaincode package does not contain code.tar.gz")
+}
+
+// readMetadataDir reads and validates files of metadata directory in lexical order
+func readMetadataDir(metadataDir string) ([]tarEntry, error) {
+	info, err := os.Stat(metadataDir)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to access chaincode metadata directory %s.\n Error: %w", metadataDir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("Chaincode metadata path %s is not a directory", metadataDir)
+	}
+	var entries []tarEntry
+	err = filepath.Walk(metadataDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(metadataDir, filePath)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		name := path.Join(strings.TrimSuffix(metadataPrefix, "/"), filepath.ToSlash(relativePath))
+		if err = validateMetadataFile(name, content); err != nil {
+			return err
+		}
+		entries = append(entries, tarEntry{name: name, content: content})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read chaincode metadata directory %s.\n Error: %w", metadataDir, err)
+	}
+	return entries, nil
+}
+
+// validateMetadataFile checks that files under statedb are CouchDB index definitions placed where peer expects them
+func validateMetadataFile(name string, content []byte) error {
+	if !strings.HasPrefix(name, metadataPrefix+"statedb/") {
+		return nil
+	}
+	if !couchDBIndexPath.MatchString(name) {
+		return fmt.Errorf("Metadata file %s is not a .json file in META-INF/statedb/couchdb/indexes or META-INF/statedb/couchdb/collections/<collection>/indexes", name)
+	}
+	_, err := parseCouchDBIndex(name, content)
+	return err
+}
+
+func parseCouchDBIndex(name string, content []byte) (*CouchDBIndex, error) {
+	definition := map[string]json.RawMessage{}
+	if err := json.Unmarshal(content, &definition); err != nil {
+		return nil, fmt.Errorf("CouchDB index %s is not valid JSON object.\n Error: %w", name, err)
+	}
+	for key := range definition {
+		switch key {
+		case "index", "ddoc", "name", "type":
+		default:
+			return nil, fmt.Errorf("CouchDB index %s contains unsupported field %q", name, key)
+		}
+	}
+	index := struct {
+		Fields []json.RawMessage `json:"fields"`
+	}{}
+	if rawIndex, ok := definition["index"]; !ok || json.Unmarshal(rawIndex, &index) != nil || len(index.Fields) == 0 {
+		return nil, fmt.Errorf("CouchDB index %s must contain index object with non empty fields", name)
+	}
+	couchDBIndex := &CouchDBIndex{Path: name}
+	for _, field := range []struct {
+		key    string
+		target *string
+	}{{"name", &couchDBIndex.Name}, {"ddoc", &couchDBIndex.DesignDoc}} {
+		if raw, ok := definition[field.key]; ok {
+			if err := json.Unmarshal(raw, field.target); err != nil {
+				return nil, fmt.Errorf("Field %s of CouchDB index %s must be string", field.key, name)
+			}
+		}
+	}
+	if rawType, ok := definition["type"]; ok {
+		var indexType string
+		if err := json.Unmarshal(rawType, &indexType); err != nil || indexType != "json" {
+			return nil, fmt.Errorf("Type of CouchDB index %s must be json", name)
+		}
+	}
+	// fields are either names or objects with name as key and sort order as value
+	for _, rawField := range index.Fields {
+		var fieldName string
+		if json.Unmarshal(rawField, &fieldName) == nil {
+			couchDBIndex.Fields = append(couchDBIndex.Fields, fieldName)
+			continue
+		}
+		sortField := map[string]string{}
+		if err := json.Unmarshal(rawField, &sortField); err != nil || len(sortField) != 1 {
+			return nil, fmt.Errorf("Field %s of CouchDB index %s must be name or object with one name and sort order", rawField, name)
+		}
+		for fieldName, order := range sortField {
+			if order != "asc" && order != "desc" {
+				return nil, fmt.Errorf("Sort order of field %s of CouchDB index %s must be asc or desc", fieldName, name)
+			}
+			couchDBIndex.Fields = append(couchDBIndex.Fields, fieldName)
+		}
+	}
+	return couchDBIndex, nil
+}
+
+func readTarGz(payload []byte) ([]tarEntry, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+	var entries []tarEntry
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			continue
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, tarEntry{name: header.Name, content: content})
+	}
+}
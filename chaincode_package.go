@@ -0,0 +1,152 @@
+package fabclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+)
+
+// ChaincodeLanguage is language chaincode is written in
+type ChaincodeLanguage string
+
+const (
+	// GoLanguage is used for Go chaincode. Chaincode path is import path inside GOPATH
+	GoLanguage ChaincodeLanguage = "golang"
+	// NodeLanguage is used for Node.js chaincode. Chaincode path is directory with package.json
+	NodeLanguage ChaincodeLanguage = "node"
+	// JavaLanguage is used for Java chaincode. Chaincode path is directory with build.gradle or pom.xml
+	JavaLanguage ChaincodeLanguage = "java"
+)
+
+// chaincodeType returns type of chaincode spec for language. Empty language is treated as Go
+func (l ChaincodeLanguage) chaincodeType() (pb.ChaincodeSpec_Type, error) {
+	switch l {
+	case "", GoLanguage:
+		return pb.ChaincodeSpec_GOLANG, nil
+	case NodeLanguage:
+		return pb.ChaincodeSpec_NODE, nil
+	case JavaLanguage:
+		return pb.ChaincodeSpec_JAVA, nil
+	default:
+		return pb.ChaincodeSpec_UNDEFINED, fmt.Errorf("Unknown chaincode language %q. Supported languages are %s, %s and %s", l, GoLanguage, NodeLanguage, JavaLanguage)
+	}
+}
+
+// packageChaincode creates chaincode package with the same layout as peer cli does. If MetadataPath is set, its content
+// replaces META-INF of package. Returned path must be used as chaincode path in install, instantiate and upgrade requests
+func packageChaincode(chaincodeParameters *ChaincodeParameters) (*resource.CCPackage, string, error) {
+	chaincodePath := chaincodeParameters.ChaincodePath
+	ccType, err := chaincodeParameters.Language.chaincodeType()
+	if err != nil {
+		return nil, "", err
+	}
+	var payload []byte
+	switch ccType {
+	case pb.ChaincodeSpec_NODE:
+		if err = validateNodeProject(chaincodePath); err != nil {
+			return nil, "", err
+		}
+		payload, err = packageProjectDir(chaincodePath, nodeExcludedDirs, nil)
+	case pb.ChaincodeSpec_JAVA:
+		if err = validateJavaProject(chaincodePath); err != nil {
+			return nil, "", err
+		}
+		payload, err = packageProjectDir(chaincodePath, javaExcludedDirs, javaExcludedFileTypes)
+	default:
+		module, moduleErr := findGoModule(chaincodePath)
+		if moduleErr != nil {
+			return nil, "", moduleErr
+		}
+		if module != nil {
+			payload, err = packageGoModule(module.dir, module.path, chaincodePath)
+			chaincodePath = module.importPath
+		} else {
+			payload, err = packageGoPath(chaincodePath)
+		}
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("Failed to create %s chaincode package with chaincode path %s.\n Error: %w", ccType, chaincodePath, err)
+	}
+	if chaincodeParameters.MetadataPath != "" {
+		if payload, err = addMetadataToPackage(payload, chaincodeParameters.MetadataPath); err != nil {
+			return nil, "", err
+		}
+	}
+	return &resource.CCPackage{Type: ccType, Code: payload}, chaincodePath, nil
+}
+
+// chaincodeRequestPath returns import path of Go chaincode located in module directory. Other paths are returned as is
+func chaincodeRequestPath(chaincodePath string, language ChaincodeLanguage) (string, error) {
+	if ccType, err := language.chaincodeType(); err != nil || ccType != pb.ChaincodeSpec_GOLANG {
+		return chaincodePath, err
+	}
+	module, err := findGoModule(chaincodePath)
+	if err != nil || module == nil {
+		return chaincodePath, err
+	}
+	return module.importPath, nil
+}
+
+// validateNodeProject checks that package.json is present and defines start script which is run by peer
+func validateNodeProject(chaincodePath string) error {
+	if err := validateProjectDir(chaincodePath, NodeLanguage); err != nil {
+		return err
+	}
+	packageJSONPath := filepath.Join(chaincodePath, "package.json")
+	content, err := ioutil.ReadFile(packageJSONPath)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("Node chaincode path %s does not contain package.json", chaincodePath)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read %s.\n Error: %w", packageJSONPath, err)
+	}
+	packageJSON := struct {
+		Scripts map[string]string `json:"scripts"`
+	}{}
+	if err = json.Unmarshal(content, &packageJSON); err != nil {
+		return fmt.Errorf("Failed to parse %s.\n Error: %w", packageJSONPath, err)
+	}
+	if packageJSON.Scripts["start"] == "" {
+		return fmt.Errorf("%s does not define start script which is used by peer to launch chaincode", packageJSONPath)
+	}
+	return nil
+}
+
+// validateJavaProject checks that Gradle or Maven build file is present
+func validateJavaProject(chaincodePath string) error {
+	if err := validateProjectDir(chaincodePath, JavaLanguage); err != nil {
+		return err
+	}
+	for _, buildFile := range []string{"build.gradle", "build.gradle.kts", "pom.xml"} {
+		if _, err := os.Stat(filepath.Join(chaincodePath, buildFile)); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("Java chaincode path %s contains neither build.gradle nor pom.xml", chaincodePath)
+}
+
+// validateProjectDir checks that chaincode path is directory and is not project of other language
+func validateProjectDir(chaincodePath string, language ChaincodeLanguage) error {
+	info, err := os.Stat(chaincodePath)
+	if err != nil {
+		return fmt.Errorf("Failed to access %s chaincode path %s.\n Error: %w", language, chaincodePath, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s chaincode path %s is not a directory", language, chaincodePath)
+	}
+	markers := map[ChaincodeLanguage]string{NodeLanguage: "package.json", JavaLanguage: "pom.xml"}
+	for otherLanguage, marker := range markers {
+		if otherLanguage == language {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(chaincodePath, marker)); err == nil {
+			return fmt.Errorf("%s chaincode path %s contains %s which belongs to %s project", language, chaincodePath, marker, otherLanguage)
+		}
+	}
+	return nil
+}
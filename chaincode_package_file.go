@@ -0,0 +1,117 @@
+package fabclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// hashFileSuffix is suffix of file which contains hash of chaincode package in sha256sum format
+const hashFileSuffix = ".sha256"
+
+// ChaincodePackage is chaincode packaged once to be installed on many peers with the same bytes
+type ChaincodePackage struct {
+	ChaincodeID string
+	Version     string
+	// Path is chaincode path which is used in install and instantiate requests
+	Path     string
+	Language ChaincodeLanguage
+	// Code is .tar.gz payload of chaincode
+	Code []byte
+	// Hash is hex encoded sha256 hash of package file
+	Hash string
+}
+
+// PackageChaincode packages chaincode described by chaincodeParameters and writes it to packagePath as ChaincodeDeploymentSpec,
+// the same format as peer chaincode package produces. Package does not depend on file times, so the same sources give
+// the same bytes. Hash of package is written to packagePath + ".sha256"
+func PackageChaincode(chaincodeParameters *ChaincodeParameters, packagePath string) (*ChaincodePackage, error) {
+	ccPkg, chaincodePath, err := packageChaincode(chaincodeParameters)
+	if err != nil {
+		return nil, err
+	}
+	deploymentSpec := &pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        ccPkg.Type,
+			ChaincodeId: &pb.ChaincodeID{Name: chaincodeParameters.ChaincodeID, Path: chaincodePath, Version: chaincodeParameters.Version},
+		},
+		CodePackage: ccPkg.Code,
+	}
+	content, err := proto.Marshal(deploymentSpec)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal deployment spec of chaincode %s version %s.\n Error: %w", chaincodeParameters.ChaincodeID, chaincodeParameters.Version, err)
+	}
+	hash := packageHash(content)
+	if err = ioutil.WriteFile(packagePath, content, 0644); err != nil {
+		return nil, fmt.Errorf("Failed to write chaincode package %s.\n Error: %w", packagePath, err)
+	}
+	if err = ioutil.WriteFile(packagePath+hashFileSuffix, []byte(fmt.Sprintf("%s  %s\n", hash, packagePath)), 0644); err != nil {
+		return nil, fmt.Errorf("Failed to write hash of chaincode package %s.\n Error: %w", packagePath, err)
+	}
+	logger.Debugf("Chaincode %s version %s packaged to %s with hash %s", chaincodeParameters.ChaincodeID, chaincodeParameters.Version, packagePath, hash)
+	return &ChaincodePackage{
+		ChaincodeID: chaincodeParameters.ChaincodeID,
+		Version:     chaincodeParameters.Version,
+		Path:        chaincodePath,
+		Language:    languageFromChaincodeType(ccPkg.Type),
+		Code:        ccPkg.Code,
+		Hash:        hash,
+	}, nil
+}
+
+// ReadChaincodePackage reads chaincode package file. If .sha256 companion file exists, content is verified against it
+func ReadChaincodePackage(packagePath string) (*ChaincodePackage, error) {
+	content, err := ioutil.ReadFile(packagePath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read chaincode package %s.\n Error: %w", packagePath, err)
+	}
+	hash := packageHash(content)
+	expectedHash, err := ioutil.ReadFile(packagePath + hashFileSuffix)
+	switch {
+	case err == nil:
+		fields := strings.Fields(string(expectedHash))
+		if len(fields) == 0 || fields[0] != hash {
+			return nil, fmt.Errorf("Hash %s of chaincode package %s does not match hash from %s", hash, packagePath, packagePath+hashFileSuffix)
+		}
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("Failed to read hash of chaincode package %s.\n Error: %w", packagePath, err)
+	}
+	deploymentSpec := &pb.ChaincodeDeploymentSpec{}
+	if err = proto.Unmarshal(content, deploymentSpec); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal chaincode package %s.\n Error: %w", packagePath, err)
+	}
+	if deploymentSpec.ChaincodeSpec == nil || deploymentSpec.ChaincodeSpec.ChaincodeId == nil {
+		return nil, fmt.Errorf("Chaincode package %s does not contain chaincode spec", packagePath)
+	}
+	chaincodeSpec := deploymentSpec.ChaincodeSpec
+	return &ChaincodePackage{
+		ChaincodeID: chaincodeSpec.ChaincodeId.Name,
+		Version:     chaincodeSpec.ChaincodeId.Version,
+		Path:        chaincodeSpec.ChaincodeId.Path,
+		Language:    languageFromChaincodeType(chaincodeSpec.Type),
+		Code:        deploymentSpec.CodePackage,
+		Hash:        hash,
+	}, nil
+}
+
+func packageHash(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func languageFromChaincodeType(ccType pb.ChaincodeSpec_Type) ChaincodeLanguage {
+	switch ccType {
+	case pb.ChaincodeSpec_NODE:
+		return NodeLanguage
+	case pb.ChaincodeSpec_JAVA:
+		return JavaLanguage
+	default:
+		return GoLanguage
+	}
+}
@@ -0,0 +1,88 @@
+package fabclient
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testIndex = `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`
+
+// writeFiles creates files with content under dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPackageGoChaincodeMetadata(t *testing.T) {
+	tests := []struct {
+		name  string
+		index string
+		fails bool
+	}{
+		{name: "index under chaincode directory is packed to package root", index: testIndex},
+		{name: "invalid index is rejected", index: `{"unknown":true}`, fails: true},
+	}
+	for _, test := range tests {
+		for _, mode := range []string{"module", "gopath"} {
+			t.Run(test.name+" in "+mode+" mode", func(t *testing.T) {
+				dir, err := ioutil.TempDir("", "chaincode")
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer os.RemoveAll(dir)
+				files := map[string]string{
+					"cc/main.go": "package main\n\nfunc main() {}\n",
+					"cc/META-INF/statedb/couchdb/indexes/indexOwner.json": test.index,
+				}
+				parameters := &ChaincodeParameters{ChaincodeID: "cc"}
+				if mode == "module" {
+					files["go.mod"] = "module example.com/chaincode\n\ngo 1.14\n"
+					files["vendor/modules.txt"] = ""
+					parameters.ChaincodePath = filepath.Join(dir, "cc")
+				} else {
+					dir = filepath.Join(dir, "gopath")
+					files = map[string]string{
+						"src/example.com/cc/main.go":                                          files["cc/main.go"],
+						"src/example.com/cc/META-INF/statedb/couchdb/indexes/indexOwner.json": test.index,
+					}
+					defer os.Setenv("GOPATH", os.Getenv("GOPATH"))
+					os.Setenv("GOPATH", dir)
+					parameters.ChaincodePath = "example.com/cc"
+				}
+				writeFiles(t, dir, files)
+				ccPackage, _, err := packageChaincode(parameters)
+				if test.fails {
+					if err == nil {
+						t.Fatal("error is expected")
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				contents, err := listPackageContents(ccPackage.Code)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(contents.Indexes) != 1 || contents.Indexes[0].Path != "META-INF/statedb/couchdb/indexes/indexOwner.json" {
+					t.Fatalf("unexpected indexes %+v", contents.Indexes)
+				}
+				for _, file := range contents.SourceFiles {
+					if strings.Contains(file, "META-INF") {
+						t.Fatalf("metadata file %s is packed as source", file)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package fabclient
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Checkpointer stores number of last block acknowledged by BlockListener so listener can resume after restart
+type Checkpointer interface {
+	// LastBlock returns number of last acknowledged block. found is false if no block was acknowledged yet
+	LastBlock() (blockNumber uint64, found bool, err error)
+	// Save stores number of last acknowledged block
+	Save(blockNumber uint64) error
+}
+
+// FileCheckpointer is Checkpointer which keeps block number in file
+type FileCheckpointer struct {
+	path string
+	lock sync.Mutex
+}
+
+// CreateFileCheckpointer creates Checkpointer which keeps block number in file with passed path
+func CreateFileCheckpointer(path string) *FileCheckpointer {
+	return &FileCheckpointer{path: path}
+}
+
+// LastBlock returns number of last acknowledged block stored in file
+func (c *FileCheckpointer) LastBlock() (uint64, bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	content, err := ioutil.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("Failed to read checkpoint file %s.\n Error: %w", c.path, err)
+	}
+	blockNumber, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("Failed to parse checkpoint file %s.\n Error: %w", c.path, err)
+	}
+	return blockNumber, true, nil
+}
+
+// Save writes block number to file. File is replaced atomically so checkpoint is never partially written
+func (c *FileCheckpointer) Save(blockNumber uint64) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	tmpFile, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("Failed to create temporary checkpoint file for %s.\n Error: %w", c.path, err)
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err = tmpFile.WriteString(strconv.FormatUint(blockNumber, 10)); err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to write checkpoint file %s.\n Error: %w", c.path, err)
+	}
+	if err = os.Rename(tmpFile.Name(), c.path); err != nil {
+		return fmt.Errorf("Failed to replace checkpoint file %s.\n Error: %w", c.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package fabclient
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// ClientCacheStats contains statistics of client cache
+type ClientCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+}
+
+type clientCacheKey struct {
+	channelID    string
+	name         string
+	organization string
+	chaincodeID  string
+	// chaincode separates chaincode clients from user clients with the same channel, user and organization
+	chaincode bool
+}
+
+type clientCacheEntry struct {
+	key      clientCacheKey
+	client   interface{}
+	lastUsed time.Time
+}
+
+// clientCache is LRU cache of user and chaincode clients with idle expiry
+type clientCache struct {
+	lock        sync.Mutex
+	maxSize     int
+	idleTimeout time.Duration
+	entries     map[clientCacheKey]*list.Element
+	order       *list.List
+	stats       ClientCacheStats
+}
+
+func newClientCache(maxSize int, idleTimeout time.Duration) *clientCache {
+	return &clientCache{
+		maxSize:     maxSize,
+		idleTimeout: idleTimeout,
+		entries:     make(map[clientCacheKey]*list.Element),
+		order:       list.New(),
+	}
+}
+
+// get returns cached client and marks it as recently used. Expired client is removed
+func (c *clientCache) get(key clientCacheKey) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	element, ok := c.entries[key]
+	if ok && c.expired(element.Value.(*clientCacheEntry), now) {
+		c.remove(element)
+		ok = false
+	}
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	entry := element.Value.(*clientCacheEntry)
+	entry.lastUsed = now
+	c.order.MoveToFront(element)
+	c.stats.Hits++
+	return entry.client, true
+}
+
+// add stores client unless other client with the same key was stored concurrently. Stored client is returned
+func (c *clientCache) add(key clientCacheKey, client interface{}) interface{} {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	if element, ok := c.entries[key]; ok && !c.expired(element.Value.(*clientCacheEntry), now) {
+		entry := element.Value.(*clientCacheEntry)
+		entry.lastUsed = now
+		c.order.MoveToFront(element)
+		return entry.client
+	} else if ok {
+		c.remove(element)
+	}
+	c.removeExpired(now)
+	c.entries[key] = c.order.PushFront(&clientCacheEntry{key: key, client: client, lastUsed: now})
+	for c.order.Len() > c.maxSize {
+		c.remove(c.order.Back())
+	}
+	return client
+}
+
+// clear removes all clients from cache
+func (c *clientCache) clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries = make(map[clientCacheKey]*list.Element)
+	c.order.Init()
+}
+
+func (c *clientCache) statistics() ClientCacheStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stats := c.stats
+	stats.Size = c.order.Len()
+	return stats
+}
+
+func (c *clientCache) expired(entry *clientCacheEntry, now time.Time) bool {
+	return c.idleTimeout > 0 && now.Sub(entry.lastUsed) > c.idleTimeout
+}
+
+// removeExpired removes expired clients starting from least recently used one
+func (c *clientCache) removeExpired(now time.Time) {
+	for element := c.order.Back(); element != nil && c.expired(element.Value.(*clientCacheEntry), now); element = c.order.Back() {
+		c.remove(element)
+	}
+}
+
+func (c *clientCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*clientCacheEntry).key)
+	c.stats.Evictions++
+}
+
+// EnableClientCache makes CreateUserClient and CreateChaincodeClient return shared clients for the same channel, user, organization and chaincode.
+// Cache keeps at most maxSize clients evicting least recently used ones. Clients unused longer than idleTimeout are evicted too, zero idleTimeout disables expiry.
+// Zero maxSize disables cache
+func (c *FabricClient) EnableClientCache(maxSize int, idleTimeout time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if maxSize <= 0 {
+		c.clients = nil
+		return
+	}
+	c.clients = newClientCache(maxSize, idleTimeout)
+}
+
+// ClientCacheStats returns hit, miss and eviction counters and current size of client cache
+func (c *FabricClient) ClientCacheStats() ClientCacheStats {
+	cache := c.clientCache()
+	if cache == nil {
+		return ClientCacheStats{}
+	}
+	return cache.statistics()
+}
+
+func (c *FabricClient) clientCache() *clientCache {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.clients
+}
@@ -0,0 +1,135 @@
+package fabclient
+
+import (
+	"testing"
+	"time"
+)
+
+func cacheKey(name string) clientCacheKey {
+	return clientCacheKey{channelID: "channel", name: name, organization: "org"}
+}
+
+func TestClientCacheEviction(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxSize       int
+		adds          []string
+		gets          []string
+		addsAfterGets []string
+		cached        []string
+		evicted       []string
+		evictions     uint64
+	}{
+		{
+			name:    "keeps clients within max size",
+			maxSize: 2,
+			adds:    []string{"a", "b"},
+			cached:  []string{"a", "b"},
+		},
+		{
+			name:      "evicts least recently added client",
+			maxSize:   2,
+			adds:      []string{"a", "b", "c"},
+			cached:    []string{"b", "c"},
+			evicted:   []string{"a"},
+			evictions: 1,
+		},
+		{
+			name:          "get marks client as recently used",
+			maxSize:       2,
+			adds:          []string{"a", "b"},
+			gets:          []string{"a"},
+			addsAfterGets: []string{"c"},
+			cached:        []string{"a", "c"},
+			evicted:       []string{"b"},
+			evictions:     1,
+		},
+		{
+			name:    "adding the same key does not evict",
+			maxSize: 1,
+			adds:    []string{"a", "a"},
+			cached:  []string{"a"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cache := newClientCache(test.maxSize, 0)
+			for _, name := range test.adds {
+				cache.add(cacheKey(name), name)
+			}
+			for _, name := range test.gets {
+				if _, ok := cache.get(cacheKey(name)); !ok {
+					t.Fatalf("client %s is not cached", name)
+				}
+			}
+			for _, name := range test.addsAfterGets {
+				cache.add(cacheKey(name), name)
+			}
+			if stats := cache.statistics(); stats.Evictions != test.evictions || stats.Size != len(test.cached) {
+				t.Fatalf("unexpected statistics %+v", stats)
+			}
+			for _, name := range test.cached {
+				if client, ok := cache.get(cacheKey(name)); !ok || client != name {
+					t.Errorf("client %s is not cached", name)
+				}
+			}
+			for _, name := range test.evicted {
+				if _, ok := cache.get(cacheKey(name)); ok {
+					t.Errorf("client %s is not evicted", name)
+				}
+			}
+		})
+	}
+}
+
+func TestClientCacheAddReturnsStoredClient(t *testing.T) {
+	cache := newClientCache(2, 0)
+	cache.add(cacheKey("a"), "first")
+	if client := cache.add(cacheKey("a"), "second"); client != "first" {
+		t.Fatalf("add returned %v instead of stored client", client)
+	}
+}
+
+func TestClientCacheIdleExpiry(t *testing.T) {
+	tests := []struct {
+		name        string
+		idleTimeout time.Duration
+		idle        time.Duration
+		cached      bool
+	}{
+		{name: "zero timeout disables expiry", idleTimeout: 0, idle: time.Hour, cached: true},
+		{name: "client used within timeout is kept", idleTimeout: time.Minute, idle: time.Second, cached: true},
+		{name: "idle client is evicted", idleTimeout: time.Minute, idle: time.Hour, cached: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cache := newClientCache(2, test.idleTimeout)
+			cache.add(cacheKey("a"), "a")
+			cache.entries[cacheKey("a")].Value.(*clientCacheEntry).lastUsed = time.Now().Add(-test.idle)
+			if _, ok := cache.get(cacheKey("a")); ok != test.cached {
+				t.Fatalf("client is cached: %v, expected %v", ok, test.cached)
+			}
+			stats := cache.statistics()
+			if test.cached && (stats.Hits != 1 || stats.Evictions != 0) {
+				t.Fatalf("unexpected statistics %+v", stats)
+			}
+			if !test.cached && (stats.Misses != 1 || stats.Evictions != 1 || stats.Size != 0) {
+				t.Fatalf("unexpected statistics %+v", stats)
+			}
+		})
+	}
+}
+
+func TestClientCacheAddRemovesExpiredClients(t *testing.T) {
+	cache := newClientCache(3, time.Minute)
+	cache.add(cacheKey("a"), "a")
+	cache.add(cacheKey("b"), "b")
+	cache.entries[cacheKey("a")].Value.(*clientCacheEntry).lastUsed = time.Now().Add(-time.Hour)
+	cache.add(cacheKey("c"), "c")
+	if stats := cache.statistics(); stats.Size != 2 || stats.Evictions != 1 {
+		t.Fatalf("unexpected statistics %+v", stats)
+	}
+	if _, ok := cache.entries[cacheKey("a")]; ok {
+		t.Fatal("expired client is not removed")
+	}
+}
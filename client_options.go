@@ -0,0 +1,117 @@
+package fabclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+)
+
+// Option configures retries and timeouts of client or of single request
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	retry              retry.Opts
+	resubmitPolicy     ResubmitPolicy
+	endorsementTimeout time.Duration
+	commitTimeout      time.Duration
+	queryTimeout       time.Duration
+}
+
+// WithRetryAttempts sets maximum number of retries of transient fabric-sdk-go failures. Zero disables retries
+func WithRetryAttempts(attempts int) Option {
+	return func(o *clientOptions) {
+		o.retry.Attempts = attempts
+	}
+}
+
+// WithRetryBackoff sets backoff between retries of transient fabric-sdk-go failures
+func WithRetryBackoff(initial time.Duration, max time.Duration, factor float64) Option {
+	return func(o *clientOptions) {
+		o.retry.InitialBackoff = initial
+		o.retry.MaxBackoff = max
+		o.retry.BackoffFactor = factor
+	}
+}
+
+// WithRetryableCodes sets fabric-sdk-go status codes which are retried. Default codes are retry.ChannelClientRetryableCodes for user and chaincode clients and retry.ResMgmtDefaultRetryableCodes for configuration client
+func WithRetryableCodes(codes map[status.Group][]status.Code) Option {
+	return func(o *clientOptions) {
+		o.retry.RetryableCodes = codes
+	}
+}
+
+// WithResubmitPolicy sets policy of resubmission of invoked transactions which are invalidated at commit
+func WithResubmitPolicy(policy ResubmitPolicy) Option {
+	return func(o *clientOptions) {
+		o.resubmitPolicy = policy
+	}
+}
+
+// WithEndorsementTimeout limits waiting for response of every endorsing peer. Zero means timeout from fabric-sdk-go config
+func WithEndorsementTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.endorsementTimeout = timeout
+	}
+}
+
+// WithCommitTimeout limits whole invoke including waiting for commit of transaction. For configuration client it limits whole resource management request.
+// Zero means timeout from fabric-sdk-go config
+func WithCommitTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.commitTimeout = timeout
+	}
+}
+
+// WithQueryTimeout limits whole query. Zero means timeout from fabric-sdk-go config
+func WithQueryTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.queryTimeout = timeout
+	}
+}
+
+func newClientOptions(defaultRetry retry.Opts, opts []Option) clientOptions {
+	return clientOptions{retry: defaultRetry}.with(opts)
+}
+
+// with returns copy of options with opts applied
+func (o clientOptions) with(opts []Option) clientOptions {
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (o clientOptions) executeOptions(ctx context.Context) []channel.RequestOption {
+	return o.channelOptions(ctx, fab.Execute, o.commitTimeout)
+}
+
+func (o clientOptions) queryOptions(ctx context.Context) []channel.RequestOption {
+	return o.channelOptions(ctx, fab.Query, o.queryTimeout)
+}
+
+func (o clientOptions) channelOptions(ctx context.Context, timeoutType fab.TimeoutType, timeout time.Duration) []channel.RequestOption {
+	options := []channel.RequestOption{channel.WithRetry(o.retry), channel.WithParentContext(ctx)}
+	if o.endorsementTimeout > 0 {
+		options = append(options, channel.WithTimeout(fab.PeerResponse, o.endorsementTimeout))
+	}
+	if timeout > 0 {
+		options = append(options, channel.WithTimeout(timeoutType, timeout))
+	}
+	return options
+}
+
+func (o clientOptions) resMgmtOptions(ctx context.Context) []resmgmt.RequestOption {
+	options := []resmgmt.RequestOption{resmgmt.WithRetry(o.retry), resmgmt.WithParentContext(ctx)}
+	if o.endorsementTimeout > 0 {
+		options = append(options, resmgmt.WithTimeout(fab.PeerResponse, o.endorsementTimeout))
+	}
+	if o.commitTimeout > 0 {
+		options = append(options, resmgmt.WithTimeout(fab.ResMgmt, o.commitTimeout))
+	}
+	return options
+}
@@ -0,0 +1,99 @@
+package fabclient
+
+import (
+	"fmt"
+	"regexp"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/policydsl"
+)
+
+// CollectionDefinition defines private data collection of chaincode
+type CollectionDefinition struct {
+	Name string
+	// MemberOrgsPolicy is signature policy of organizations which store private data, e.g. OR('Org1MSP.member','Org2MSP.member')
+	MemberOrgsPolicy string
+	// RequiredPeerCount is number of peers private data must be disseminated to before endorsement
+	RequiredPeerCount int32
+	// MaxPeerCount is maximum number of peers private data is disseminated to
+	MaxPeerCount int32
+	// BlockToLive is number of blocks private data is kept for. Zero means forever
+	BlockToLive uint64
+	// MemberOnlyRead allows only members of collection to read private data
+	MemberOnlyRead bool
+}
+
+var collectionNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// collectionConfigs validates collection definitions of chaincode and converts them to collection configs. Configs from Collections field
+// are validated the same way as converted definitions
+func (p *ChaincodeParameters) collectionConfigs() ([]*pb.CollectionConfig, error) {
+	names := make(map[string]bool)
+	configs := make([]*pb.CollectionConfig, 0, len(p.CollectionDefinitions)+len(p.Collections))
+	for _, definition := range p.CollectionDefinitions {
+		config, err := definition.collectionConfig()
+		if err != nil {
+			return nil, fmt.Errorf("Invalid collection %s of chaincode %s.\n Error: %w", definition.Name, p.ChaincodeID, err)
+		}
+		configs = append(configs, config)
+	}
+	for _, config := range p.Collections {
+		static := config.GetStaticCollectionConfig()
+		if static == nil {
+			return nil, fmt.Errorf("Collection config of chaincode %s is not static collection config", p.ChaincodeID)
+		}
+		if err := validateStaticCollectionConfig(static); err != nil {
+			return nil, fmt.Errorf("Invalid collection %s of chaincode %s.\n Error: %w", static.Name, p.ChaincodeID, err)
+		}
+		configs = append(configs, config)
+	}
+	for _, config := range configs {
+		name := config.GetStaticCollectionConfig().GetName()
+		if names[name] {
+			return nil, fmt.Errorf("Collection %s of chaincode %s is defined more than once", name, p.ChaincodeID)
+		}
+		names[name] = true
+	}
+	return configs, nil
+}
+
+func (d CollectionDefinition) collectionConfig() (*pb.CollectionConfig, error) {
+	if d.MemberOrgsPolicy == "" {
+		return nil, fmt.Errorf("Member organizations policy is required")
+	}
+	policy, err := policydsl.FromString(d.MemberOrgsPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to construct member organizations policy from string %s.\n Error: %w", d.MemberOrgsPolicy, err)
+	}
+	static := &pb.StaticCollectionConfig{
+		Name: d.Name,
+		MemberOrgsPolicy: &pb.CollectionPolicyConfig{
+			Payload: &pb.CollectionPolicyConfig_SignaturePolicy{SignaturePolicy: policy},
+		},
+		RequiredPeerCount: d.RequiredPeerCount,
+		MaximumPeerCount:  d.MaxPeerCount,
+		BlockToLive:       d.BlockToLive,
+		MemberOnlyRead:    d.MemberOnlyRead,
+	}
+	if err = validateStaticCollectionConfig(static); err != nil {
+		return nil, err
+	}
+	return &pb.CollectionConfig{Payload: &pb.CollectionConfig_StaticCollectionConfig{StaticCollectionConfig: static}}, nil
+}
+
+// validateStaticCollectionConfig checks collection config the same way for definitions and configs passed as is. BlockToLive is unsigned so it is always valid
+func validateStaticCollectionConfig(config *pb.StaticCollectionConfig) error {
+	if !collectionNamePattern.MatchString(config.Name) {
+		return fmt.Errorf("Collection name %q must contain only letters, digits, '_' and '-'", config.Name)
+	}
+	if config.RequiredPeerCount < 0 {
+		return fmt.Errorf("Required peer count %d must not be negative", config.RequiredPeerCount)
+	}
+	if config.MaximumPeerCount < config.RequiredPeerCount {
+		return fmt.Errorf("Maximum peer count %d must not be less than required peer count %d", config.MaximumPeerCount, config.RequiredPeerCount)
+	}
+	if config.MemberOrgsPolicy.GetSignaturePolicy() == nil {
+		return fmt.Errorf("Member organizations signature policy is required")
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package fabclient
+
+import (
+	"testing"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/policydsl"
+)
+
+func staticCollection(name string, options ...func(*pb.StaticCollectionConfig)) *pb.CollectionConfig {
+	policy, err := policydsl.FromString("OR('Org1MSP.member')")
+	if err != nil {
+		panic(err)
+	}
+	config := &pb.StaticCollectionConfig{
+		Name:              name,
+		MemberOrgsPolicy:  &pb.CollectionPolicyConfig{Payload: &pb.CollectionPolicyConfig_SignaturePolicy{SignaturePolicy: policy}},
+		RequiredPeerCount: 0,
+		MaximumPeerCount:  1,
+	}
+	for _, option := range options {
+		option(config)
+	}
+	return &pb.CollectionConfig{Payload: &pb.CollectionConfig_StaticCollectionConfig{StaticCollectionConfig: config}}
+}
+
+func TestCollectionConfigs(t *testing.T) {
+	definition := func(name string) CollectionDefinition {
+		return CollectionDefinition{Name: name, MemberOrgsPolicy: "OR('Org1MSP.member')", RequiredPeerCount: 0, MaxPeerCount: 1}
+	}
+	tests := []struct {
+		name        string
+		definitions []CollectionDefinition
+		collections []*pb.CollectionConfig
+		count       int
+		fails       bool
+	}{
+		{name: "names with underscore and dash", definitions: []CollectionDefinition{definition("private_data-1")}, collections: []*pb.CollectionConfig{staticCollection("raw_collection")}, count: 2},
+		{name: "invalid definition name", definitions: []CollectionDefinition{definition("private data")}, fails: true},
+		{name: "nil static collection config", collections: []*pb.CollectionConfig{{}}, fails: true},
+		{name: "empty raw collection name", collections: []*pb.CollectionConfig{staticCollection("")}, fails: true},
+		{
+			name:        "raw collection without member organizations policy",
+			collections: []*pb.CollectionConfig{staticCollection("private", func(c *pb.StaticCollectionConfig) { c.MemberOrgsPolicy = nil })},
+			fails:       true,
+		},
+		{
+			name:        "raw collection with negative required peer count",
+			collections: []*pb.CollectionConfig{staticCollection("private", func(c *pb.StaticCollectionConfig) { c.RequiredPeerCount = -1 })},
+			fails:       true,
+		},
+		{
+			name:        "raw collection with negative maximum peer count",
+			collections: []*pb.CollectionConfig{staticCollection("private", func(c *pb.StaticCollectionConfig) { c.MaximumPeerCount = -1 })},
+			fails:       true,
+		},
+		{
+			name:        "raw collection with required peer count above maximum",
+			collections: []*pb.CollectionConfig{staticCollection("private", func(c *pb.StaticCollectionConfig) { c.RequiredPeerCount = 2 })},
+			fails:       true,
+		},
+		{name: "duplicated name", definitions: []CollectionDefinition{definition("private")}, collections: []*pb.CollectionConfig{staticCollection("private")}, fails: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parameters := &ChaincodeParameters{ChaincodeID: "chaincode", CollectionDefinitions: test.definitions, Collections: test.collections}
+			configs, err := parameters.collectionConfigs()
+			if test.fails {
+				if err == nil {
+					t.Fatal("error is expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(configs) != test.count {
+				t.Fatalf("got %d collection configs, expected %d", len(configs), test.count)
+			}
+		})
+	}
+}
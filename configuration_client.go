@@ -1,18 +1,19 @@
 package fabclient
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
+	pb "github.com/hyperledger/fabric-protos-go/peer"
 	mspclient "github.com/hyperledger/fabric-sdk-go/pkg/client/msp"
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
-	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
-	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
-	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
-	platform "github.com/hyperledger/fabric/core/chaincode/platforms/golang"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/policydsl"
 )
 
 // ConfigurationClient
@@ -21,6 +22,7 @@ type ConfigurationClient struct {
 	name          string
 	organization  string
 	fabricClient  *FabricClient
+	options       clientOptions
 }
 
 // ChannelParameters contains data used to call functions that requires struct as argument
@@ -29,23 +31,51 @@ type ChannelParameters struct {
 	ChannelConfigPath string
 }
 
-// ChaincodeParameters is representation for parameters used to interact with chaincode
+// ChaincodeParameters is representation for parameters used to interact with chaincode. Empty Language means Go chaincode.
+// MetadataPath is optional META-INF directory with CouchDB indexes which is packed instead of META-INF of chaincode sources.
+// Label, PackageID, Sequence and InitRequired are used by Fabric 2.x lifecycle only. Private data collections are validated before they are sent
 type ChaincodeParameters struct {
 	ChaincodeID   string
 	ChaincodePath string
 	Version       string
 	ArgsForInit   [][]byte
 	Policy        string
+	Language      ChaincodeLanguage
+	MetadataPath  string
+	Label         string
+	PackageID     string
+	Sequence      int64
+	InitRequired  bool
+	Collections   []*pb.CollectionConfig
+	// CollectionDefinitions are sent along with Collections
+	CollectionDefinitions []CollectionDefinition
 }
 
-// CreateChannelParameters constructs ChannelParameters
+// CreateChannelParameters constructs ChannelParameters. Channel config path may be absolute or relative to working directory
 func CreateChannelParameters(channelID string, channelConfigPath string) *ChannelParameters {
 	return &ChannelParameters{
 		ChannelID:         channelID,
-		ChannelConfigPath: os.Getenv("GOPATH") + channelConfigPath,
+		ChannelConfigPath: resolvePath(channelConfigPath),
 	}
 }
 
+// resolvePath returns absolute path of existing file. Path inside GOPATH is kept for backward compatibility
+// if it does not exist relative to working directory
+func resolvePath(filePath string) string {
+	if _, err := os.Stat(filePath); err != nil {
+		if gopath := os.Getenv("GOPATH"); gopath != "" {
+			if _, err = os.Stat(filepath.Join(gopath, filePath)); err == nil {
+				return filepath.Join(gopath, filePath)
+			}
+		}
+	}
+	absolutePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return filePath
+	}
+	return absolutePath
+}
+
 // CreateChaincodeParameters used to construct ChaincodeParameters
 func CreateChaincodeParameters(chaincodeID string, chaincodePath string, version string, argsForInit [][]byte, policy string) *ChaincodeParameters {
 	return &ChaincodeParameters{
@@ -58,12 +88,12 @@ func CreateChaincodeParameters(chaincodeID string, chaincodePath string, version
 }
 
 // CreateConfigurationClient is the same as  (c *FabricClient) CreateConfigurationClient(channelID string, name string, organization string) but it does not reuse Fabric Client
-func CreateConfigurationClient(configPath string, ordererHost string, name string, organization string) (*ConfigurationClient, error) {
+func CreateConfigurationClient(configPath string, ordererHost string, name string, organization string, opts ...Option) (*ConfigurationClient, error) {
 	fabricClient, err := CreateFabricClient(configPath, ordererHost)
 	if err != nil {
 		return nil, err
 	}
-	return fabricClient.CreateConfigurationClient(name, organization)
+	return fabricClient.CreateConfigurationClient(name, organization, opts...)
 }
 
 // CreateChannelFromStructure the sames as CreateChannel but accepts ChannelParameters struct
@@ -73,68 +103,257 @@ func (c *ConfigurationClient) CreateChannelFromStructure(channelParameters *Chan
 
 // CreateChannel creates channel
 func (c *ConfigurationClient) CreateChannel(channelID string, channelConfigPath string) error {
-	// logger.Debugf("Creating channel %s", channelID)
-	mspClient, err := mspclient.New(c.fabricClient.sdk.Context(), mspclient.WithOrg(c.organization))
-	if err != nil {
-		return fmt.Errorf("Failed to create msp client with organisation %s.\n Error: %s", c.organization, err)
-	}
-	userIdentity, err := mspClient.GetSigningIdentity(c.name)
+	return c.CreateChannelContext(context.Background(), channelID, channelConfigPath)
+}
+
+// CreateChannelContext is the same as CreateChannel but request is bound to ctx
+func (c *ConfigurationClient) CreateChannelContext(ctx context.Context, channelID string, channelConfigPath string) error {
+	_, err := c.CreateChannelWithOrderer(ctx, channelID, channelConfigPath)
+	return err
+}
+
+// CreateChannelWithOrderer is the same as CreateChannelContext but also returns endpoint of orderer which served request.
+// Endpoint is empty if orderer is taken from channel config
+func (c *ConfigurationClient) CreateChannelWithOrderer(ctx context.Context, channelID string, channelConfigPath string) (string, error) {
+	var ordererEndpoint string
+	err := c.fabricClient.do(ctx, func() error {
+		// logger.Debugf("Creating channel %s", channelID)
+		mspClient, err := mspclient.New(c.fabricClient.sdk.Context(), mspclient.WithOrg(c.organization))
+		if err != nil {
+			return fmt.Errorf("Failed to create msp client with organisation %s.\n Error: %w", c.organization, err)
+		}
+		userIdentity, err := mspClient.GetSigningIdentity(c.name)
+		if err != nil {
+			return fmt.Errorf("Failed to get signing identity %s while creating channel [%s].\n Error: %w", c.name, channelID, err)
+		}
+		req := resmgmt.SaveChannelRequest{ChannelID: channelID, ChannelConfigPath: channelConfigPath, SigningIdentities: []msp.SigningIdentity{userIdentity}}
+		var txID resmgmt.SaveChannelResponse
+		ordererEndpoint, err = c.withOrderer(ctx, func(ordererOptions []resmgmt.RequestOption) error {
+			var saveErr error
+			txID, saveErr = c.resMgmtClient.SaveChannel(req, append(ordererOptions, c.options.resMgmtOptions(ctx)...)...)
+			return saveErr
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to save channel %s.\n Error: %w", channelID, classifyError("", string(txID.TransactionID), err))
+		}
+		if txID.TransactionID == "" {
+			return fmt.Errorf("Failed to save channel %s: transaction id is empty", channelID)
+		}
+		logger.Debugf("Channel %s created by orderer %s", channelID, ordererEndpoint)
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("Failed to get signing identity %s while creating channel [%s].\n Error: %v", c.name, channelID, err)
-	}
-	req := resmgmt.SaveChannelRequest{ChannelID: channelID, ChannelConfigPath: channelConfigPath, SigningIdentities: []msp.SigningIdentity{userIdentity}}
-	txID, err := c.resMgmtClient.SaveChannel(req, resmgmt.WithOrdererEndpoint(c.fabricClient.ordererHost))
-	if err != nil || txID.TransactionID == "" {
-		return fmt.Errorf("Failed to save channel %s.\n Error: %s", channelID, err)
+		return "", err
 	}
-	logger.Debugf("Channel %s created", channelID)
-	return nil
+	return ordererEndpoint, nil
 }
 
-// InstallChaincodeFromStructure the sames as InstallChaincode but accepts ChaincodeParameters struct
+// InstallChaincodeFromStructure the sames as InstallChaincode but accepts ChaincodeParameters struct. Chaincode is packaged according to its Language
 func (c *ConfigurationClient) InstallChaincodeFromStructure(chaincodeParameters *ChaincodeParameters) error {
-	return c.InstallChaincode(chaincodeParameters.ChaincodeID, chaincodeParameters.ChaincodePath, chaincodeParameters.Version)
+	return c.InstallChaincodeFromStructureContext(context.Background(), chaincodeParameters)
+}
+
+// InstallChaincodeFromStructureContext is the same as InstallChaincodeFromStructure but request is bound to ctx
+func (c *ConfigurationClient) InstallChaincodeFromStructureContext(ctx context.Context, chaincodeParameters *ChaincodeParameters) error {
+	return c.installChaincode(ctx, chaincodeParameters)
 }
 
-// InstallChaincode installs chaincode
+// InstallChaincode installs Go chaincode
 func (c *ConfigurationClient) InstallChaincode(chaincodeID string, chaincodePath string, version string) error {
+	return c.InstallChaincodeContext(context.Background(), chaincodeID, chaincodePath, version)
+}
+
+// InstallChaincodeContext is the same as InstallChaincode but request is bound to ctx
+func (c *ConfigurationClient) InstallChaincodeContext(ctx context.Context, chaincodeID string, chaincodePath string, version string) error {
+	return c.installChaincode(ctx, &ChaincodeParameters{ChaincodeID: chaincodeID, ChaincodePath: chaincodePath, Version: version})
+}
+
+func (c *ConfigurationClient) installChaincode(ctx context.Context, chaincodeParameters *ChaincodeParameters) error {
+	chaincodeID, version := chaincodeParameters.ChaincodeID, chaincodeParameters.Version
 	// logger.Debugf("Installing chaincode %s version %s", chaincodeID, version)
-	goPlatform := platform.Platform{}
-	payload, err := goPlatform.GetDeploymentPayload(chaincodePath)
+	ccPkg, chaincodePath, err := packageChaincode(chaincodeParameters)
 	if err != nil {
-		return fmt.Errorf("Failed to create chaincode package with chaincode path %s.\n Error: %v", chaincodePath, err)
+		return err
 	}
-	ccPkg := &resource.CCPackage{Type: pb.ChaincodeSpec_GOLANG, Code: payload}
-	// Install example cc to org peers
-	installCCReq := resmgmt.InstallCCRequest{Name: chaincodeID, Path: chaincodePath, Version: version, Package: ccPkg}
-	_, err = c.resMgmtClient.InstallCC(installCCReq, resmgmt.WithRetry(retry.DefaultResMgmtOpts))
+	return c.installPackage(ctx, chaincodeID, chaincodePath, version, ccPkg)
+}
+
+func (c *ConfigurationClient) installPackage(ctx context.Context, chaincodeID string, chaincodePath string, version string, ccPkg *resource.CCPackage) error {
+	return c.fabricClient.do(ctx, func() error {
+		// Install example cc to org peers
+		installCCReq := resmgmt.InstallCCRequest{Name: chaincodeID, Path: chaincodePath, Version: version, Package: ccPkg}
+		if _, err := c.resMgmtClient.InstallCC(installCCReq, c.options.resMgmtOptions(ctx)...); err != nil {
+			return fmt.Errorf("Failed to install chaincode with chaincode id %s, chaincode path %s and version %s.\n Error: %w", chaincodeID, chaincodePath, version, classifyError(chaincodeID, "", err))
+		}
+		logger.Debugf("Chaincode %s version %s installed", chaincodeID, version)
+		return nil
+	})
+}
+
+// InstallChaincodePackage installs chaincode from package file created by PackageChaincode. If file has .sha256 companion
+// file created alongside with package, content of package is verified against it before installation
+func (c *ConfigurationClient) InstallChaincodePackage(packagePath string) error {
+	return c.InstallChaincodePackageContext(context.Background(), packagePath)
+}
+
+// InstallChaincodePackageContext is the same as InstallChaincodePackage but request is bound to ctx
+func (c *ConfigurationClient) InstallChaincodePackageContext(ctx context.Context, packagePath string) error {
+	chaincodePackage, err := ReadChaincodePackage(packagePath)
 	if err != nil {
-		return fmt.Errorf("Failed to install chaincode with chaincode id %s, chaincode path and version %s.\n Error: %v", chaincodeID, chaincodePath, version, err)
+		return err
 	}
-	logger.Debugf("Chaincode %s version %s installed", chaincodeID, version)
-	return nil
+	ccType, err := chaincodePackage.Language.chaincodeType()
+	if err != nil {
+		return err
+	}
+	logger.Debugf("Installing chaincode package %s with hash %s", packagePath, chaincodePackage.Hash)
+	return c.installPackage(ctx, chaincodePackage.ChaincodeID, chaincodePackage.Path, chaincodePackage.Version, &resource.CCPackage{Type: ccType, Code: chaincodePackage.Code})
 }
 
 // InstanciateChaincodeFromStructure the sames as InstanciateChaincode but accepts ChaincodeParameters struct
 func (c *ConfigurationClient) InstanciateChaincodeFromStructure(channelID string, chaincodeParameters *ChaincodeParameters) error {
-	return c.InstanciateChaincode(channelID, chaincodeParameters.ChaincodeID, chaincodeParameters.ChaincodePath, chaincodeParameters.Version, chaincodeParameters.ArgsForInit, chaincodeParameters.Policy)
+	return c.InstanciateChaincodeFromStructureContext(context.Background(), channelID, chaincodeParameters)
+}
+
+// InstanciateChaincodeFromStructureContext is the same as InstanciateChaincodeFromStructure but request is bound to ctx
+func (c *ConfigurationClient) InstanciateChaincodeFromStructureContext(ctx context.Context, channelID string, chaincodeParameters *ChaincodeParameters) error {
+	return c.instanciateChaincode(ctx, channelID, chaincodeParameters)
 }
 
-// InstanciateChaincode instantiates chaincode
+// InstanciateChaincode instantiates Go chaincode
 func (c *ConfigurationClient) InstanciateChaincode(channelID string, chaincodeID string, chaincodePath string, version string, args [][]byte, policy string) error {
-	// logger.Debugf("Instantiating chaincode %s version %s", chaincodeID, version)
-	ccPolicy, err := cauthdsl.FromString(policy)
+	return c.InstanciateChaincodeContext(context.Background(), channelID, chaincodeID, chaincodePath, version, args, policy)
+}
+
+// InstanciateChaincodeContext is the same as InstanciateChaincode but request is bound to ctx
+func (c *ConfigurationClient) InstanciateChaincodeContext(ctx context.Context, channelID string, chaincodeID string, chaincodePath string, version string, args [][]byte, policy string) error {
+	return c.instanciateChaincode(ctx, channelID, CreateChaincodeParameters(chaincodeID, chaincodePath, version, args, policy))
+}
+
+func (c *ConfigurationClient) instanciateChaincode(ctx context.Context, channelID string, chaincodeParameters *ChaincodeParameters) error {
+	return c.fabricClient.do(ctx, func() error {
+		chaincodeID, chaincodePath, version, args, policy := chaincodeParameters.ChaincodeID, chaincodeParameters.ChaincodePath, chaincodeParameters.Version, chaincodeParameters.ArgsForInit, chaincodeParameters.Policy
+		// logger.Debugf("Instantiating chaincode %s version %s", chaincodeID, version)
+		ccPolicy, err := policydsl.FromString(policy)
+		if err != nil {
+			return fmt.Errorf("Failed to construct signature policy from string %s.\n Error: %w", policy, err)
+		}
+		ccType, err := chaincodeParameters.Language.chaincodeType()
+		if err != nil {
+			return err
+		}
+		chaincodePath, err = chaincodeRequestPath(chaincodePath, chaincodeParameters.Language)
+		if err != nil {
+			return err
+		}
+		collections, err := chaincodeParameters.collectionConfigs()
+		if err != nil {
+			return err
+		}
+		resp, err := c.resMgmtClient.InstantiateCC(channelID,
+			resmgmt.InstantiateCCRequest{Name: chaincodeID, Path: chaincodePath, Version: version, Lang: ccType, Args: args, Policy: ccPolicy, CollConfig: collections},
+			c.options.resMgmtOptions(ctx)...,
+		)
+		if err != nil {
+			return fmt.Errorf("Failed to instantiate the chaincode with channelID: %s, chaincodeID: %s, chaincodePath: %s, version: %s, args: %v and signature policy: %s.\n Error: %w", channelID, chaincodeID, chaincodePath, version, args, policy, classifyError(chaincodeID, string(resp.TransactionID), err))
+		}
+		if resp.TransactionID == "" {
+			return fmt.Errorf("Failed to instantiate the chaincode %s version %s on channel %s: transaction id is empty", chaincodeID, version, channelID)
+		}
+		logger.Debugf("Chaincode %s version %s instantiated", chaincodeID, version)
+		return nil
+	})
+}
+
+// UpgradeChaincodeFromStructure the sames as UpgradeChaincode but accepts ChaincodeParameters struct
+func (c *ConfigurationClient) UpgradeChaincodeFromStructure(channelID string, chaincodeParameters *ChaincodeParameters) error {
+	return c.upgradeChaincode(context.Background(), channelID, chaincodeParameters)
+}
+
+// UpgradeChaincode upgrades instantiated Go chaincode to new version. New version must be installed before upgrade
+func (c *ConfigurationClient) UpgradeChaincode(channelID string, chaincodeID string, chaincodePath string, version string, args [][]byte, policy string) error {
+	return c.UpgradeChaincodeContext(context.Background(), channelID, chaincodeID, chaincodePath, version, args, policy)
+}
+
+// UpgradeChaincodeContext is the same as UpgradeChaincode but request is bound to ctx
+func (c *ConfigurationClient) UpgradeChaincodeContext(ctx context.Context, channelID string, chaincodeID string, chaincodePath string, version string, args [][]byte, policy string) error {
+	return c.upgradeChaincode(ctx, channelID, CreateChaincodeParameters(chaincodeID, chaincodePath, version, args, policy))
+}
+
+func (c *ConfigurationClient) upgradeChaincode(ctx context.Context, channelID string, chaincodeParameters *ChaincodeParameters) error {
+	return c.fabricClient.do(ctx, func() error {
+		chaincodeID, chaincodePath, version, args, policy := chaincodeParameters.ChaincodeID, chaincodeParameters.ChaincodePath, chaincodeParameters.Version, chaincodeParameters.ArgsForInit, chaincodeParameters.Policy
+		// logger.Debugf("Upgrading chaincode %s version %s", chaincodeID, version)
+		ccPolicy, err := policydsl.FromString(policy)
+		if err != nil {
+			return fmt.Errorf("Failed to construct signature policy from string %s.\n Error: %w", policy, err)
+		}
+		ccType, err := chaincodeParameters.Language.chaincodeType()
+		if err != nil {
+			return err
+		}
+		chaincodePath, err = chaincodeRequestPath(chaincodePath, chaincodeParameters.Language)
+		if err != nil {
+			return err
+		}
+		collections, err := chaincodeParameters.collectionConfigs()
+		if err != nil {
+			return err
+		}
+		resp, err := c.resMgmtClient.UpgradeCC(channelID,
+			resmgmt.UpgradeCCRequest{Name: chaincodeID, Path: chaincodePath, Version: version, Lang: ccType, Args: args, Policy: ccPolicy, CollConfig: collections},
+			c.options.resMgmtOptions(ctx)...,
+		)
+		if err != nil {
+			return fmt.Errorf("Failed to upgrade the chaincode with channelID: %s, chaincodeID: %s, chaincodePath: %s, version: %s, args: %v and signature policy: %s.\n Error: %w", channelID, chaincodeID, chaincodePath, version, args, policy, classifyError(chaincodeID, string(resp.TransactionID), err))
+		}
+		if resp.TransactionID == "" {
+			return fmt.Errorf("Failed to upgrade the chaincode %s version %s on channel %s: transaction id is empty", chaincodeID, version, channelID)
+		}
+		logger.Debugf("Chaincode %s upgraded to version %s", chaincodeID, version)
+		return nil
+	})
+}
+
+// DeployChaincode instantiates chaincode if it is not instantiated on channel yet or upgrades it if other version is instantiated.
+// Nothing is done if the same version is already instantiated
+func (c *ConfigurationClient) DeployChaincode(channelID string, chaincodeParameters *ChaincodeParameters) error {
+	return c.DeployChaincodeContext(context.Background(), channelID, chaincodeParameters)
+}
+
+// DeployChaincodeContext is the same as DeployChaincode but requests are bound to ctx
+func (c *ConfigurationClient) DeployChaincodeContext(ctx context.Context, channelID string, chaincodeParameters *ChaincodeParameters) error {
+	version, instantiated, err := c.instantiatedVersion(ctx, channelID, chaincodeParameters.ChaincodeID)
 	if err != nil {
-		return fmt.Errorf("Failed to construct signature policy from string %s.\n Error: %v", policy, err)
+		return err
 	}
-	resp, err := c.resMgmtClient.InstantiateCC(channelID,
-		resmgmt.InstantiateCCRequest{Name: chaincodeID, Path: chaincodePath, Version: version, Args: args, Policy: ccPolicy},
-	)
-	if err != nil || resp.TransactionID == "" {
-		return fmt.Errorf("Failed to instantiate the chaincode with channelID: %s, chaincodeID: %s, chaincodePath: %s, version: %s, args: %v and signature policy: %s.\n Error: %v", channelID, chaincodeID, chaincodePath, version, args, policy, err)
+	switch {
+	case !instantiated:
+		return c.instanciateChaincode(ctx, channelID, chaincodeParameters)
+	case version != chaincodeParameters.Version:
+		return c.upgradeChaincode(ctx, channelID, chaincodeParameters)
+	default:
+		logger.Debugf("Chaincode %s version %s is already instantiated on channel %s", chaincodeParameters.ChaincodeID, version, channelID)
+		return nil
 	}
-	logger.Debugf("Chaincode %s version %s instantiated", chaincodeID, version)
-	return nil
+}
+
+// instantiatedVersion returns version of chaincode instantiated on channel. instantiated is false if chaincode is not instantiated
+func (c *ConfigurationClient) instantiatedVersion(ctx context.Context, channelID string, chaincodeID string) (version string, instantiated bool, err error) {
+	err = c.fabricClient.do(ctx, func() error {
+		resp, err := c.resMgmtClient.QueryInstantiatedChaincodes(channelID, c.options.resMgmtOptions(ctx)...)
+		if err != nil {
+			return fmt.Errorf("Failed to query instantiated chaincodes on channel %s.\n Error: %w", channelID, err)
+		}
+		for _, chaincode := range resp.Chaincodes {
+			if chaincode.Name == chaincodeID {
+				version, instantiated = chaincode.Version, true
+				return nil
+			}
+		}
+		return nil
+	})
+	return version, instantiated, err
 }
 
 // JoinChannelFromStructure the sames as JoinChannel but accepts ChannelParameters struct
@@ -144,38 +363,95 @@ func (c *ConfigurationClient) JoinChannelFromStructure(channelParameters *Channe
 
 // JoinChannel joins channel
 func (c *ConfigurationClient) JoinChannel(channelID string) error {
-	// logger.Debugf("Joining channel %s", channelID)
-	if err := c.resMgmtClient.JoinChannel(channelID, resmgmt.WithRetry(retry.DefaultResMgmtOpts), resmgmt.WithOrdererEndpoint(c.fabricClient.ordererHost)); err != nil {
-		return fmt.Errorf("Failed to join channel %s.\n Error: %v", channelID, err)
+	return c.JoinChannelContext(context.Background(), channelID)
+}
+
+// JoinChannelContext is the same as JoinChannel but request is bound to ctx
+func (c *ConfigurationClient) JoinChannelContext(ctx context.Context, channelID string) error {
+	_, err := c.JoinChannelWithOrderer(ctx, channelID)
+	return err
+}
+
+// JoinChannelWithOrderer is the same as JoinChannelContext but also returns endpoint of orderer which served genesis block.
+// Endpoint is empty if orderer is taken from channel config
+func (c *ConfigurationClient) JoinChannelWithOrderer(ctx context.Context, channelID string) (string, error) {
+	var ordererEndpoint string
+	err := c.fabricClient.do(ctx, func() error {
+		// logger.Debugf("Joining channel %s", channelID)
+		var err error
+		ordererEndpoint, err = c.withOrderer(ctx, func(ordererOptions []resmgmt.RequestOption) error {
+			return c.resMgmtClient.JoinChannel(channelID, append(ordererOptions, c.options.resMgmtOptions(ctx)...)...)
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to join channel %s.\n Error: %w", channelID, classifyError("", "", err))
+		}
+		logger.Debugf("Channel %s joined with genesis block from orderer %s", channelID, ordererEndpoint)
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
-	logger.Debugf("Channel %s joined", channelID)
-	return nil
+	return ordererEndpoint, nil
 }
 
 // CreateAndJoinChannelFromStructure the sames as CreateAndJoinChannel but accepts ChannelParameters struct
 func (c *ConfigurationClient) CreateAndJoinChannelFromStructure(channelParameters *ChannelParameters) error {
+	return c.CreateAndJoinChannelFromStructureContext(context.Background(), channelParameters)
+}
+
+// CreateAndJoinChannelFromStructureContext is the same as CreateAndJoinChannelFromStructure but both requests are bound to ctx
+func (c *ConfigurationClient) CreateAndJoinChannelFromStructureContext(ctx context.Context, channelParameters *ChannelParameters) error {
 	var err error
-	err = c.CreateChannelFromStructure(channelParameters)
+	err = c.CreateChannelContext(ctx, channelParameters.ChannelID, channelParameters.ChannelConfigPath)
 	if err != nil {
-		return fmt.Errorf("Failed to create channel with structure %+v.\n Error: %v", channelParameters, err)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, ErrClosed) {
+			return err
+		}
+		return fmt.Errorf("Failed to create channel with structure %+v.\n Error: %w", channelParameters, err)
 	}
-	err = c.JoinChannelFromStructure(channelParameters)
+	err = c.JoinChannelContext(ctx, channelParameters.ChannelID)
 	if err != nil {
-		return fmt.Errorf("Failed to join channel with structure %+v.\n Error: %v", channelParameters, err)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, ErrClosed) {
+			return err
+		}
+		return fmt.Errorf("Failed to join channel with structure %+v.\n Error: %w", channelParameters, err)
 	}
 	return nil
 }
 
 // CreateAndJoinChannel creates and joins channel
 func (c *ConfigurationClient) CreateAndJoinChannel(channelID string, channelConfigPath string) error {
+	return c.CreateAndJoinChannelContext(context.Background(), channelID, channelConfigPath)
+}
+
+// CreateAndJoinChannelContext is the same as CreateAndJoinChannel but both requests are bound to ctx
+func (c *ConfigurationClient) CreateAndJoinChannelContext(ctx context.Context, channelID string, channelConfigPath string) error {
 	var err error
-	err = c.CreateChannel(channelID, channelConfigPath)
+	err = c.CreateChannelContext(ctx, channelID, channelConfigPath)
 	if err != nil {
-		return fmt.Errorf("Failed to create channel with channelID %s and channelConfigPath %s.\n Error: %v", channelID, channelConfigPath, err)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, ErrClosed) {
+			return err
+		}
+		return fmt.Errorf("Failed to create channel with channelID %s and channelConfigPath %s.\n Error: %w", channelID, channelConfigPath, err)
 	}
-	err = c.JoinChannel(channelID)
+	err = c.JoinChannelContext(ctx, channelID)
 	if err != nil {
-		return fmt.Errorf("Failed to join channel with channelID %s.\n Error: %v", channelID, err)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, ErrClosed) {
+			return err
+		}
+		return fmt.Errorf("Failed to join channel with channelID %s.\n Error: %w", channelID, err)
 	}
 	return nil
 }
@@ -186,7 +462,7 @@ func (c *ConfigurationClient) initResourceMgmtClient(sdk *fabsdk.FabricSDK) erro
 	resourceManagerClientContext := c.fabricClient.sdk.Context(fabsdk.WithUser(c.name), fabsdk.WithOrg(c.organization))
 	resMgmtClient, err := resmgmt.New(resourceManagerClientContext)
 	if err != nil {
-		return fmt.Errorf("Failed to create channel management client with user %s and organisation %s.\n Error: %v", c.name, c.organization, err)
+		return fmt.Errorf("Failed to create channel management client with user %s and organisation %s.\n Error: %w", c.name, c.organization, err)
 	}
 	c.resMgmtClient = resMgmtClient
 	logger.Debug("Ressource management client created")
@@ -0,0 +1,241 @@
+package fabclient
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	mspclient "github.com/hyperledger/fabric-sdk-go/pkg/client/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	pkgerrors "github.com/pkg/errors"
+)
+
+var (
+	// ErrChaincode matches errors returned by chaincode
+	ErrChaincode = errors.New("chaincode returned error")
+	// ErrEndorsement matches errors of endorsement which are not returned by chaincode itself
+	ErrEndorsement = errors.New("endorsement failed")
+	// ErrEndorsementMismatch matches errors caused by different responses of endorsing peers
+	ErrEndorsementMismatch = errors.New("endorsement responses do not match")
+	// ErrInvalidTransaction matches errors of transactions which are committed with validation code other than VALID
+	ErrInvalidTransaction = errors.New("transaction is invalid")
+	// ErrMVCCConflict matches errors of transactions which are invalidated because of read conflict
+	ErrMVCCConflict = errors.New("transaction has read conflict")
+	// ErrTimeout matches errors of requests which are not completed in time by fabric-sdk-go
+	ErrTimeout = errors.New("request timed out")
+	// ErrIdentityNotFound matches errors caused by missing user identity
+	ErrIdentityNotFound = errors.New("user identity is not found")
+	// ErrTransactionNotFound matches errors of ledger queries of transactions which are not committed to channel
+	ErrTransactionNotFound = errors.New("transaction is not found")
+)
+
+// ChaincodeError is returned when chaincode responds with error status
+type ChaincodeError struct {
+	ChaincodeID string
+	Status      int32
+	Message     string
+	Peer        string
+	Err         error
+}
+
+func (e *ChaincodeError) Error() string {
+	return fmt.Sprintf("chaincode %s returned status %d: %s", e.ChaincodeID, e.Status, e.Message)
+}
+
+// Unwrap returns original fabric-sdk-go error
+func (e *ChaincodeError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrChaincode
+func (e *ChaincodeError) Is(target error) bool {
+	return target == ErrChaincode
+}
+
+// Retryable is false because chaincode returns the same error for the same request
+func (e *ChaincodeError) Retryable() bool {
+	return false
+}
+
+// EndorsementError is returned when endorsement fails not because of chaincode: responses mismatch, peer is unavailable or refuses proposal
+type EndorsementError struct {
+	Code    int32
+	Message string
+	Peer    string
+	Err     error
+}
+
+func (e *EndorsementError) Error() string {
+	if e.Peer != "" {
+		return fmt.Sprintf("endorsement failed on peer %s with code %d: %s", e.Peer, e.Code, e.Message)
+	}
+	return fmt.Sprintf("endorsement failed with code %d: %s", e.Code, e.Message)
+}
+
+// Unwrap returns original fabric-sdk-go error
+func (e *EndorsementError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrEndorsement or ErrEndorsementMismatch for mismatched responses
+func (e *EndorsementError) Is(target error) bool {
+	return target == ErrEndorsement || (target == ErrEndorsementMismatch && e.mismatch())
+}
+
+// Retryable reports whether request may succeed if it is sent again
+func (e *EndorsementError) Retryable() bool {
+	switch e.Code {
+	case status.EndorsementMismatch.ToInt32(), status.ConnectionFailed.ToInt32(), status.GenericTransient.ToInt32(), status.Timeout.ToInt32():
+		return true
+	default:
+		return false
+	}
+}
+
+func (e *EndorsementError) mismatch() bool {
+	return e.Code == status.EndorsementMismatch.ToInt32()
+}
+
+// TransactionError is returned when transaction is committed with validation code other than VALID
+type TransactionError struct {
+	TxID           string
+	ValidationCode pb.TxValidationCode
+	Err            error
+}
+
+func (e *TransactionError) Error() string {
+	return fmt.Sprintf("transaction %s is invalid with validation code %s", e.TxID, e.ValidationCode)
+}
+
+// Unwrap returns original fabric-sdk-go error
+func (e *TransactionError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidTransaction or ErrMVCCConflict for read conflicts
+func (e *TransactionError) Is(target error) bool {
+	return target == ErrInvalidTransaction || (target == ErrMVCCConflict && e.conflict())
+}
+
+// Retryable is true for read conflicts because transaction resubmitted with fresh id may succeed
+func (e *TransactionError) Retryable() bool {
+	return e.conflict()
+}
+
+func (e *TransactionError) conflict() bool {
+	return e.ValidationCode == pb.TxValidationCode_MVCC_READ_CONFLICT || e.ValidationCode == pb.TxValidationCode_PHANTOM_READ_CONFLICT
+}
+
+// TimeoutError is returned when fabric-sdk-go request is not completed in time. Deadline of request context is returned as context.DeadlineExceeded instead
+type TimeoutError struct {
+	Message string
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("request timed out: %s", e.Message)
+}
+
+// Unwrap returns original fabric-sdk-go error
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrTimeout
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// Retryable is true because timed out request may succeed if it is sent again
+func (e *TimeoutError) Retryable() bool {
+	return true
+}
+
+// IdentityError is returned when signing identity of user can not be obtained
+type IdentityError struct {
+	Name         string
+	Organization string
+	Err          error
+}
+
+func (e *IdentityError) Error() string {
+	return fmt.Sprintf("failed to get identity of user %s in organization %s: %v", e.Name, e.Organization, e.Err)
+}
+
+// Unwrap returns original fabric-sdk-go error
+func (e *IdentityError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrIdentityNotFound for missing users
+func (e *IdentityError) Is(target error) bool {
+	return target == ErrIdentityNotFound && errors.Is(e.Err, mspclient.ErrUserNotFound)
+}
+
+// Retryable is false because identity does not appear without enrollment
+func (e *IdentityError) Retryable() bool {
+	return false
+}
+
+// isTransactionNotFound reports whether ledger query failed because peer does not have transaction in its index
+func isTransactionNotFound(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "Entry not found in index") || strings.Contains(message, "no such transaction ID")
+}
+
+// isDuplicateTransaction reports whether peer rejected proposal because transaction with the same id is already committed
+func isDuplicateTransaction(err error) bool {
+	return strings.Contains(err.Error(), "duplicate transaction found")
+}
+
+// IsRetryable reports whether request which failed with err may succeed if it is sent again
+func IsRetryable(err error) bool {
+	var retryable interface{ Retryable() bool }
+	return errors.As(err, &retryable) && retryable.Retryable()
+}
+
+var endorserPattern = regexp.MustCompile(`endorser \[([^\]]+)\]`)
+
+// classifyError converts fabric-sdk-go error to one of typed errors. Unknown errors are returned as is
+func classifyError(chaincodeID string, txID string, err error) error {
+	if err == nil {
+		return nil
+	}
+	s, ok := sdkStatus(err)
+	if !ok {
+		return err
+	}
+	peer := ""
+	if match := endorserPattern.FindStringSubmatch(err.Error()); match != nil {
+		peer = match[1]
+	}
+	switch s.Group {
+	case status.ChaincodeStatus:
+		return &ChaincodeError{ChaincodeID: chaincodeID, Status: s.Code, Message: s.Message, Peer: peer, Err: err}
+	case status.EndorserClientStatus, status.EndorserServerStatus:
+		return &EndorsementError{Code: s.Code, Message: s.Message, Peer: peer, Err: err}
+	case status.EventServerStatus:
+		return &TransactionError{TxID: txID, ValidationCode: pb.TxValidationCode(s.Code), Err: err}
+	case status.ClientStatus:
+		if s.Code == status.Timeout.ToInt32() {
+			return &TimeoutError{Message: s.Message, Err: err}
+		}
+	}
+	return err
+}
+
+// sdkStatus extracts status of fabric-sdk-go error. If several peers failed, first error with status is used
+func sdkStatus(err error) (*status.Status, bool) {
+	if errs, ok := pkgerrors.Cause(err).(multi.Errors); ok {
+		for _, e := range errs {
+			if s, ok := status.FromError(e); ok {
+				return s, true
+			}
+		}
+		return nil, false
+	}
+	return status.FromError(err)
+}
@@ -0,0 +1,67 @@
+package fabclient
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Unsubscribe stops delivery of events and closes events channel. It is safe to call it several times
+type Unsubscribe func()
+
+// SubscribeEvents subscribes on events of chaincode which names match filter regular expression.
+// Events are delivered to returned channel until Unsubscribe is called or Fabric Client is closed.
+// Events are received from filtered blocks, so Payload of events is empty
+func (c *UserClient) SubscribeEvents(chaincodeID string, filter string) (<-chan *ChaincodeEvent, Unsubscribe, error) {
+	if err := c.fabricClient.checkOpen(); err != nil {
+		return nil, nil, err
+	}
+	eventClient, err := c.fabricClient.txStatusEvents(c.channelID, c.channelProvider)
+	if err != nil {
+		return nil, nil, err
+	}
+	registration, ccEvents, err := eventClient.events.RegisterChaincodeEvent(chaincodeID, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to register for events of chaincode %s with filter %s.\n Error: %w", chaincodeID, filter, err)
+	}
+	events := make(chan *ChaincodeEvent)
+	done := make(chan struct{})
+	go func() {
+		defer close(events)
+		for ccEvent := range ccEvents {
+			chaincodeEvent := &ChaincodeEvent{
+				ChaincodeID: ccEvent.ChaincodeID,
+				TxID:        ccEvent.TxID,
+				EventName:   ccEvent.EventName,
+				Payload:     ccEvent.Payload,
+				BlockNumber: ccEvent.BlockNumber,
+			}
+			select {
+			case events <- chaincodeEvent:
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			close(done)
+			c.fabricClient.untrack(done)
+			eventClient.events.Unregister(registration)
+			logger.Debugf("Unsubscribed from events of chaincode %s with filter %s", chaincodeID, filter)
+		})
+	}
+	if err = c.fabricClient.track(done, unsubscribe); err != nil {
+		unsubscribe()
+		return nil, nil, err
+	}
+	logger.Debugf("Subscribed on events of chaincode %s with filter %s", chaincodeID, filter)
+	return events, unsubscribe, nil
+}
+
+// SubscribeEvents subscribes on events of chaincode which names match filter regular expression.
+// Events are delivered to returned channel until Unsubscribe is called or Fabric Client is closed.
+// Events are received from filtered blocks, so Payload of events is empty
+func (c *ChaincodeClient) SubscribeEvents(filter string) (<-chan *ChaincodeEvent, Unsubscribe, error) {
+	return c.userClient.SubscribeEvents(c.chaincodeID, filter)
+}
@@ -0,0 +1,169 @@
+package fabclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"time"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	lcpackager "github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/lifecycle"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+)
+
+// externalChaincodeType is type of chaincode package which is built by external builder
+const externalChaincodeType = "external"
+
+// packageLabel is format of label allowed by peer
+var packageLabel = regexp.MustCompile(`^[[:alnum:]][[:alnum:]_.+-]*$`)
+
+// ExternalChaincodeParameters describes chaincode which runs as external service and is connected by peer (chaincode-as-a-service).
+// Certificates and key are PEM encoded
+type ExternalChaincodeParameters struct {
+	Label              string
+	Address            string
+	DialTimeout        time.Duration
+	TLSRequired        bool
+	ClientAuthRequired bool
+	ClientKey          string
+	ClientCert         string
+	RootCert           string
+	// Type is type of package in metadata.json which is matched by external builder. Default is external
+	Type string
+}
+
+type connectionJSON struct {
+	Address            string `json:"address"`
+	DialTimeout        string `json:"dial_timeout"`
+	TLSRequired        bool   `json:"tls_required"`
+	ClientAuthRequired bool   `json:"client_auth_required"`
+	ClientKey          string `json:"client_key"`
+	ClientCert         string `json:"client_cert"`
+	RootCert           string `json:"root_cert"`
+}
+
+type metadataJSON struct {
+	Type  string `json:"type"`
+	Label string `json:"label"`
+}
+
+// CreateExternalChaincodeParameters used to construct ExternalChaincodeParameters without TLS and with 10 seconds dial timeout
+func CreateExternalChaincodeParameters(label string, address string) *ExternalChaincodeParameters {
+	return &ExternalChaincodeParameters{
+		Label:       label,
+		Address:     address,
+		DialTimeout: 10 * time.Second,
+		Type:        externalChaincodeType,
+	}
+}
+
+// PackageExternalChaincode creates Fabric 2.x chaincode package with connection.json and metadata.json for external builder
+func PackageExternalChaincode(externalParameters *ExternalChaincodeParameters) ([]byte, error) {
+	code, err := externalParameters.codePackage("connection.json")
+	if err != nil {
+		return nil, err
+	}
+	packageType := externalParameters.Type
+	if packageType == "" {
+		packageType = externalChaincodeType
+	}
+	metadata, err := json.Marshal(metadataJSON{Type: packageType, Label: externalParameters.Label})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal metadata.json of external chaincode %s.\n Error: %w", externalParameters.Label, err)
+	}
+	ccPkg, err := writeTarGz([]tarEntry{{name: "code.tar.gz", content: code}, {name: "metadata.json", content: metadata}})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create package of external chaincode %s.\n Error: %w", externalParameters.Label, err)
+	}
+	return ccPkg, nil
+}
+
+// codePackage returns code.tar.gz with connection.json stored under connectionPath
+func (p *ExternalChaincodeParameters) codePackage(connectionPath string) ([]byte, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	connection, err := json.Marshal(connectionJSON{
+		Address:            p.Address,
+		DialTimeout:        p.DialTimeout.String(),
+		TLSRequired:        p.TLSRequired,
+		ClientAuthRequired: p.ClientAuthRequired,
+		ClientKey:          p.ClientKey,
+		ClientCert:         p.ClientCert,
+		RootCert:           p.RootCert,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal connection.json of external chaincode %s.\n Error: %w", p.Label, err)
+	}
+	code, err := writeTarGz([]tarEntry{{name: connectionPath, content: connection}})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to pack connection.json of external chaincode %s.\n Error: %w", p.Label, err)
+	}
+	return code, nil
+}
+
+// InstallExternalChaincode packages and installs external chaincode on peers of organization. Package id is returned and
+// is used to approve chaincode definition the same way as for chaincode installed by LifecycleInstallChaincode
+func (c *ConfigurationClient) InstallExternalChaincode(externalParameters *ExternalChaincodeParameters) (string, error) {
+	return c.InstallExternalChaincodeContext(context.Background(), externalParameters)
+}
+
+// InstallExternalChaincodeContext is the same as InstallExternalChaincode but request is bound to ctx
+func (c *ConfigurationClient) InstallExternalChaincodeContext(ctx context.Context, externalParameters *ExternalChaincodeParameters) (string, error) {
+	var packageID string
+	err := c.fabricClient.do(ctx, func() error {
+		ccPkg, err := PackageExternalChaincode(externalParameters)
+		if err != nil {
+			return err
+		}
+		_, err = c.resMgmtClient.LifecycleInstallCC(resmgmt.LifecycleInstallCCRequest{Label: externalParameters.Label, Package: ccPkg},
+			c.options.resMgmtOptions(ctx)...)
+		if err != nil {
+			return fmt.Errorf("Failed to install external chaincode with label %s and address %s.\n Error: %w", externalParameters.Label, externalParameters.Address, classifyError("", "", err))
+		}
+		packageID = lcpackager.ComputePackageID(externalParameters.Label, ccPkg)
+		logger.Debugf("External chaincode package %s for address %s installed", packageID, externalParameters.Address)
+		return nil
+	})
+	return packageID, err
+}
+
+// InstallExternalChaincodeLegacy installs external chaincode with legacy lifecycle under chaincodeID and version. Chaincode is
+// instantiated by InstanciateChaincode with the same chaincodeID and version and with label as chaincode path.
+// Peer sets chaincode language instead of package type in metadata.json of legacy package, so external builder should detect
+// such package by src/connection.json in its source
+func (c *ConfigurationClient) InstallExternalChaincodeLegacy(chaincodeID string, version string, externalParameters *ExternalChaincodeParameters) error {
+	return c.InstallExternalChaincodeLegacyContext(context.Background(), chaincodeID, version, externalParameters)
+}
+
+// InstallExternalChaincodeLegacyContext is the same as InstallExternalChaincodeLegacy but request is bound to ctx
+func (c *ConfigurationClient) InstallExternalChaincodeLegacyContext(ctx context.Context, chaincodeID string, version string, externalParameters *ExternalChaincodeParameters) error {
+	// peer accepts only files inside src and META-INF directories in legacy Go package
+	code, err := externalParameters.codePackage("src/connection.json")
+	if err != nil {
+		return err
+	}
+	return c.installPackage(ctx, chaincodeID, externalParameters.Label, version, &resource.CCPackage{Type: pb.ChaincodeSpec_GOLANG, Code: code})
+}
+
+func (p *ExternalChaincodeParameters) validate() error {
+	if !packageLabel.MatchString(p.Label) {
+		return fmt.Errorf("Label %q of external chaincode must start with letter or digit and contain only letters, digits, '_', '.', '+' and '-'", p.Label)
+	}
+	if _, _, err := net.SplitHostPort(p.Address); err != nil {
+		return fmt.Errorf("Address %q of external chaincode %s must be host:port.\n Error: %w", p.Address, p.Label, err)
+	}
+	if p.DialTimeout < 0 {
+		return fmt.Errorf("Dial timeout of external chaincode %s must not be negative", p.Label)
+	}
+	if p.TLSRequired && p.RootCert == "" {
+		return fmt.Errorf("Root certificate of external chaincode %s is required when TLS is required", p.Label)
+	}
+	if p.ClientAuthRequired && (!p.TLSRequired || p.ClientKey == "" || p.ClientCert == "") {
+		return fmt.Errorf("Client authentication of external chaincode %s requires TLS, client key and client certificate", p.Label)
+	}
+	return nil
+}
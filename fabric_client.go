@@ -1,10 +1,18 @@
+// Package fabclient is wrapper on fabric-sdk-go with different API to interact with.
+//
+// Methods with Context suffix bind requests to ctx. If ctx is cancelled or its deadline exceeded before request is finished, ctx.Err() is returned
 package fabclient
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/ledger"
 	mspclient "github.com/hyperledger/fabric-sdk-go/pkg/client/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config"
@@ -13,10 +21,23 @@ import (
 
 var logger = logging.NewLogger("fabclient")
 
+// ErrClosed is returned by Fabric Client and clients created from it after Fabric Client is closed
+var ErrClosed = errors.New("fabric client is closed")
+
 // FabricClient contains FabricSDK and used to interact with fabric system
 type FabricClient struct {
-	sdk         *fabsdk.FabricSDK
-	ordererHost string
+	sdk      *fabsdk.FabricSDK
+	orderers *ordererPool
+	lock     sync.Mutex
+	closed   bool
+	inFlight sync.WaitGroup
+	clients  *clientCache
+	// txEvents contains event clients of channels used to await commits of asynchronous invokes and waited transactions
+	txEvents map[string]*txStatusEvents
+	// shutdown is closed right before connections are closed
+	shutdown chan struct{}
+	// stops contains stop functions of started block listeners, event subscriptions and outboxes which are called on close
+	stops map[interface{}]func()
 }
 
 // CreateFabricClient creates new Fabric Client
@@ -25,29 +46,194 @@ func CreateFabricClient(configPath string, ordererHost string) (*FabricClient, e
 	cp := config.FromFile(configPath)
 	sdk, err := fabsdk.New(cp)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to read fabric SDK config file: %s", err)
+		return nil, fmt.Errorf("Failed to read fabric SDK config file: %w", err)
 	}
 	fabricClient := CreateFabricClientFromSDK(sdk, ordererHost)
 	return fabricClient, nil
 }
 
+// CreateFabricClientFromConfig creates new Fabric Client from typed config without config file
+func CreateFabricClientFromConfig(cfg *Config, ordererHost string) (*FabricClient, error) {
+	cp, err := cfg.ConfigProvider()
+	if err != nil {
+		return nil, err
+	}
+	sdk, err := fabsdk.New(cp)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create fabric SDK from config.\n Error: %w", err)
+	}
+	return CreateFabricClientFromSDK(sdk, ordererHost), nil
+}
+
+// CreateFabricClientFromRaw creates new Fabric Client from content of fabric-sdk-go config file. configType is yaml or json
+func CreateFabricClientFromRaw(raw []byte, configType string, ordererHost string) (*FabricClient, error) {
+	sdk, err := fabsdk.New(config.FromRaw(raw, configType))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read fabric SDK %s config.\n Error: %w", configType, err)
+	}
+	return CreateFabricClientFromSDK(sdk, ordererHost), nil
+}
+
+// CreateFabricClientWithOrderers is the same as CreateFabricClient but accepts several orderer endpoints.
+// Channel administration requests are sent to next orderer according to selection if orderer fails
+func CreateFabricClientWithOrderers(configPath string, ordererHosts []string, selection OrdererSelection) (*FabricClient, error) {
+	cp := config.FromFile(configPath)
+	sdk, err := fabsdk.New(cp)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read fabric SDK config file: %w", err)
+	}
+	return CreateFabricClientFromSDKWithOrderers(sdk, ordererHosts, selection), nil
+}
+
 // CreateFabricClientFromSDK creates new Fabric Client based on passed sdk
 func CreateFabricClientFromSDK(sdk *fabsdk.FabricSDK, ordererHost string) *FabricClient {
+	var ordererHosts []string
+	if ordererHost != "" {
+		ordererHosts = []string{ordererHost}
+	}
+	return CreateFabricClientFromSDKWithOrderers(sdk, ordererHosts, OrderedFailover)
+}
+
+// CreateFabricClientFromSDKWithOrderers creates new Fabric Client based on passed sdk with several orderer endpoints.
+// If there are no endpoints, orderers are taken from channel config
+func CreateFabricClientFromSDKWithOrderers(sdk *fabsdk.FabricSDK, ordererHosts []string, selection OrdererSelection) *FabricClient {
 	FabricClient := FabricClient{
-		ordererHost: ordererHost,
-		sdk:         sdk,
+		orderers: newOrdererPool(ordererHosts, selection),
+		sdk:      sdk,
+		shutdown: make(chan struct{}),
 	}
 	logger.Debug("fabric-client created")
 	return &FabricClient
 }
 
-// CreateConfigurationClient creates new Configuration Client
-func (c *FabricClient) CreateConfigurationClient(name string, organization string) (*ConfigurationClient, error) {
+// OrdererEndpoints returns orderer endpoints of Fabric Client
+func (c *FabricClient) OrdererEndpoints() []string {
+	return append([]string(nil), c.orderers.endpoints...)
+}
+
+// Close closes connections of Fabric Client immediately. Clients created from Fabric Client return ErrClosed after that
+func (c *FabricClient) Close() {
+	if !c.markClosed() {
+		return
+	}
+	c.closeConnections()
+}
+
+// CloseGracefully rejects new requests with ErrClosed and waits for in-flight requests to finish before closing connections.
+// If ctx is done before in-flight requests are finished, connections are closed anyway and ctx.Err() is returned
+func (c *FabricClient) CloseGracefully(ctx context.Context) error {
+	if !c.markClosed() {
+		return nil
+	}
+	finished := make(chan struct{})
+	go func() {
+		c.inFlight.Wait()
+		close(finished)
+	}()
+	var err error
+	select {
+	case <-finished:
+	case <-ctx.Done():
+		err = ctx.Err()
+		logger.Warnf("fabric-client closed before in-flight requests are finished.\n Error: %v", err)
+	}
+	c.closeConnections()
+	return err
+}
+
+// closeConnections releases commit waiters, stops listeners, subscriptions and outboxes, releases cached clients and closes sdk
+func (c *FabricClient) closeConnections() {
+	close(c.shutdown)
+	c.lock.Lock()
+	c.txEvents = nil
+	stops := c.stops
+	c.stops = nil
+	c.lock.Unlock()
+	for _, stop := range stops {
+		stop()
+	}
+	c.clearClientCache()
+	c.sdk.Close()
+	logger.Debug("fabric-client closed")
+}
+
+// markClosed returns false if Fabric Client is already closed
+func (c *FabricClient) markClosed() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		return false
+	}
+	c.closed = true
+	return true
+}
+
+// acquire registers in-flight request so graceful close waits for it. Returned release must be called when request is finished
+func (c *FabricClient) acquire() (release func(), err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		return nil, ErrClosed
+	}
+	c.inFlight.Add(1)
+	return c.inFlight.Done, nil
+}
+
+// do runs request as in-flight request so graceful close waits for it. If request fails after ctx is done, ctx.Err() is returned instead of its error
+func (c *FabricClient) do(ctx context.Context, request func() error) error {
+	release, err := c.acquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+	if err = request(); err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// track registers stop function of key which is called when Fabric Client is closed. ErrClosed is returned if Fabric Client is closed
+func (c *FabricClient) track(key interface{}, stop func()) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	if c.stops == nil {
+		c.stops = make(map[interface{}]func())
+	}
+	c.stops[key] = stop
+	return nil
+}
+
+// untrack removes stop function of key registered by track
+func (c *FabricClient) untrack(key interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.stops, key)
+}
+
+// checkOpen returns ErrClosed if Fabric Client is closed
+func (c *FabricClient) checkOpen() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	return nil
+}
+
+// CreateConfigurationClient creates new Configuration Client. opts configure retries and timeouts of its requests
+func (c *FabricClient) CreateConfigurationClient(name string, organization string, opts ...Option) (*ConfigurationClient, error) {
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
 	var err error
 	configurationClient := &ConfigurationClient{
 		name:         name,
 		organization: organization,
 		fabricClient: c,
+		options:      newClientOptions(retry.DefaultResMgmtOpts, opts),
 	}
 	err = configurationClient.initResourceMgmtClient(c.sdk)
 	if err != nil {
@@ -57,21 +243,57 @@ func (c *FabricClient) CreateConfigurationClient(name string, organization strin
 	return configurationClient, nil
 }
 
-// CreateUserClient creates new User Client
-func (c *FabricClient) CreateUserClient(channelID string, name string, organization string) (*UserClient, error) {
+// CreateUserClient creates new User Client. opts configure retries and timeouts of its requests.
+// If client cache is enabled, client shares connections with cached one
+func (c *FabricClient) CreateUserClient(channelID string, name string, organization string, opts ...Option) (*UserClient, error) {
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
+	cache := c.clientCache()
+	key := clientCacheKey{channelID: channelID, name: name, organization: organization}
+	if cache != nil {
+		if cached, ok := cache.get(key); ok {
+			return cached.(*UserClient).withOptions(opts), nil
+		}
+	}
+	userClient, err := c.newUserClient(channelID, name, organization)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		userClient = cache.add(key, userClient).(*UserClient)
+	}
+	return userClient.withOptions(opts), nil
+}
+
+func (c *FabricClient) newUserClient(channelID string, name string, organization string) (*UserClient, error) {
 	var err error
 	userClient := &UserClient{
 		name:         name,
 		organization: organization,
 		channelID:    channelID,
+		fabricClient: c,
+		options:      newClientOptions(retry.DefaultChannelOpts, nil),
 	}
 
 	channelProvider := c.sdk.ChannelContext(userClient.channelID, fabsdk.WithUser(userClient.name), fabsdk.WithOrg(userClient.organization))
 	clientInstance, err := channel.New(channelProvider)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to create user client with channel id %s, user name %s and organization %s.\n Error: %v", userClient.channelID, userClient.name, userClient.organization, err)
+		return nil, fmt.Errorf("Failed to create user client with channel id %s, user name %s and organization %s.\n Error: %w", userClient.channelID, userClient.name, userClient.organization, err)
 	}
 	userClient.channelClient = clientInstance
+	userClient.channelProvider = channelProvider
+	ledgerInstance, err := ledger.New(channelProvider)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create ledger client with channel id %s, user name %s and organization %s.\n Error: %w", channelID, name, organization, err)
+	}
+	userClient.ledgerClient = &LedgerClient{
+		name:         name,
+		organization: organization,
+		channelID:    channelID,
+		ledgerClient: ledgerInstance,
+		fabricClient: c,
+	}
 
 	userClient.signingIdentity, err = c.getUserIdentity(userClient.name, userClient.organization)
 	if err != nil {
@@ -81,28 +303,60 @@ func (c *FabricClient) CreateUserClient(channelID string, name string, organizat
 	return userClient, nil
 }
 
-// CreateChaincodeClient creates new Chaincode Client
-func (c *FabricClient) CreateChaincodeClient(channelID string, chaincodeID string, name string, organization string) (*ChaincodeClient, error) {
+// CreateChaincodeClient creates new Chaincode Client. opts configure retries and timeouts of its requests.
+// If client cache is enabled, client shares connections with cached one
+func (c *FabricClient) CreateChaincodeClient(channelID string, chaincodeID string, name string, organization string, opts ...Option) (*ChaincodeClient, error) {
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
+	cache := c.clientCache()
+	key := clientCacheKey{channelID: channelID, name: name, organization: organization, chaincodeID: chaincodeID, chaincode: true}
+	if cache != nil {
+		if cached, ok := cache.get(key); ok {
+			return cached.(*ChaincodeClient).withOptions(opts), nil
+		}
+	}
+	chaincodeClient, err := c.newChaincodeClient(channelID, chaincodeID, name, organization)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		chaincodeClient = cache.add(key, chaincodeClient).(*ChaincodeClient)
+	}
+	return chaincodeClient.withOptions(opts), nil
+}
+
+func (c *FabricClient) newChaincodeClient(channelID string, chaincodeID string, name string, organization string) (*ChaincodeClient, error) {
 	var err error
 	chaincodeClient := &ChaincodeClient{
 		chaincodeID: chaincodeID,
 	}
 	chaincodeClient.userClient, err = c.CreateUserClient(channelID, name, organization)
+	if errors.Is(err, ErrClosed) {
+		return nil, err
+	}
 	if err != nil {
-		return nil, fmt.Errorf("Failed to create user client with channel id %s, user name %s and organization %s.\n Error: %v", channelID, name, organization, err)
+		return nil, fmt.Errorf("Failed to create user client with channel id %s, user name %s and organization %s.\n Error: %w", channelID, name, organization, err)
 	}
 	logger.Debugf("Chaincode client for channelID: %s, chaincodeID: %s, user: %s and organization: %screated", channelID, chaincodeID, name, organization)
 	return chaincodeClient, nil
 }
 
+// clearClientCache drops cached clients so closed connections are not served anymore
+func (c *FabricClient) clearClientCache() {
+	if cache := c.clientCache(); cache != nil {
+		cache.clear()
+	}
+}
+
 func (c *FabricClient) getUserIdentity(name string, organization string) (msp.SigningIdentity, error) {
 	mspClient, err := mspclient.New(c.sdk.Context(), mspclient.WithOrg(organization))
 	if err != nil {
-		return nil, fmt.Errorf("Failed to create msp client with organisation %s.\n Error: %v", name, err)
+		return nil, fmt.Errorf("Failed to create msp client with organisation %s.\n Error: %w", name, err)
 	}
 	userIdentity, err := mspClient.GetSigningIdentity(name)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to get user signing identity with name: %s.\n Error: %v", name, err)
+		return nil, fmt.Errorf("Failed to get user signing identity with name: %s.\n Error: %w", name, &IdentityError{Name: name, Organization: organization, Err: err})
 	}
 	return userIdentity, nil
 }
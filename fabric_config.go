@@ -0,0 +1,303 @@
+package fabclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Config is typed configuration of fabric-sdk-go. It is used to create Fabric Client without config file
+type Config struct {
+	// Organization is organization of client
+	Organization        string               `json:"organization" yaml:"organization"`
+	LogLevel            string               `json:"logLevel" yaml:"logLevel"`
+	CryptoConfigPath    string               `json:"cryptoConfigPath" yaml:"cryptoConfigPath"`
+	CredentialStorePath string               `json:"credentialStorePath" yaml:"credentialStorePath"`
+	CryptoStorePath     string               `json:"cryptoStorePath" yaml:"cryptoStorePath"`
+	Organizations       []OrganizationConfig `json:"organizations" yaml:"organizations"`
+	Peers               []NodeConfig         `json:"peers" yaml:"peers"`
+	Orderers            []NodeConfig         `json:"orderers" yaml:"orderers"`
+	Channels            []ChannelConfig      `json:"channels" yaml:"channels"`
+}
+
+// OrganizationConfig describes organization. CryptoPath is relative to CryptoConfigPath and may contain {username}.
+// Users are identities kept in memory instead of crypto store
+type OrganizationConfig struct {
+	Name       string       `json:"name" yaml:"name"`
+	MSPID      string       `json:"mspID" yaml:"mspID"`
+	CryptoPath string       `json:"cryptoPath" yaml:"cryptoPath"`
+	Peers      []string     `json:"peers" yaml:"peers"`
+	Users      []UserConfig `json:"users" yaml:"users"`
+}
+
+// UserConfig is identity of user with PEM encoded certificate and private key
+type UserConfig struct {
+	Name    string `json:"name" yaml:"name"`
+	CertPEM string `json:"certPEM" yaml:"certPEM"`
+	KeyPEM  string `json:"keyPEM" yaml:"keyPEM"`
+}
+
+// NodeConfig describes peer or orderer. TLS CA certificate is set either by path or PEM
+type NodeConfig struct {
+	Name                  string `json:"name" yaml:"name"`
+	URL                   string `json:"url" yaml:"url"`
+	TLSCACertPath         string `json:"tlsCACertPath" yaml:"tlsCACertPath"`
+	TLSCACertPEM          string `json:"tlsCACertPEM" yaml:"tlsCACertPEM"`
+	SSLTargetNameOverride string `json:"sslTargetNameOverride" yaml:"sslTargetNameOverride"`
+	AllowInsecure         bool   `json:"allowInsecure" yaml:"allowInsecure"`
+}
+
+// ChannelConfig describes channel and peers of client organization joined to it
+type ChannelConfig struct {
+	ID    string   `json:"id" yaml:"id"`
+	Peers []string `json:"peers" yaml:"peers"`
+}
+
+// CreateConfig constructs Config for client of organization
+func CreateConfig(organization string) *Config {
+	return &Config{Organization: organization}
+}
+
+// ParseConfig parses Config from raw bytes. configType is yaml or json
+func ParseConfig(raw []byte, configType string) (*Config, error) {
+	cfg := &Config{}
+	var err error
+	switch strings.ToLower(configType) {
+	case "yaml", "yml":
+		err = yaml.Unmarshal(raw, cfg)
+	case "json":
+		err = json.Unmarshal(raw, cfg)
+	default:
+		return nil, fmt.Errorf("Unknown config type %s. Supported types are yaml and json", configType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse %s config.\n Error: %w", configType, err)
+	}
+	return cfg, nil
+}
+
+// AddOrganization adds organization to config
+func (c *Config) AddOrganization(organization OrganizationConfig) *Config {
+	c.Organizations = append(c.Organizations, organization)
+	return c
+}
+
+// AddPeer adds peer to config
+func (c *Config) AddPeer(peer NodeConfig) *Config {
+	c.Peers = append(c.Peers, peer)
+	return c
+}
+
+// AddOrderer adds orderer to config
+func (c *Config) AddOrderer(orderer NodeConfig) *Config {
+	c.Orderers = append(c.Orderers, orderer)
+	return c
+}
+
+// AddChannel adds channel to config
+func (c *Config) AddChannel(channel ChannelConfig) *Config {
+	c.Channels = append(c.Channels, channel)
+	return c
+}
+
+// OverrideFromEnv overrides config with environment variables. Supported variables are
+// <prefix>_ORGANIZATION, <prefix>_LOG_LEVEL, <prefix>_CRYPTO_CONFIG_PATH, <prefix>_CREDENTIAL_STORE_PATH, <prefix>_CRYPTO_STORE_PATH,
+// <prefix>_PEER_<name>_URL, <prefix>_PEER_<name>_TLS_CA_CERT_PATH, <prefix>_ORDERER_<name>_URL and <prefix>_ORDERER_<name>_TLS_CA_CERT_PATH.
+// Name of node is upper cased and all characters except letters and digits are replaced by underscore
+func (c *Config) OverrideFromEnv(prefix string) *Config {
+	overrideFromEnv(&c.Organization, prefix, "ORGANIZATION")
+	overrideFromEnv(&c.LogLevel, prefix, "LOG_LEVEL")
+	overrideFromEnv(&c.CryptoConfigPath, prefix, "CRYPTO_CONFIG_PATH")
+	overrideFromEnv(&c.CredentialStorePath, prefix, "CREDENTIAL_STORE_PATH")
+	overrideFromEnv(&c.CryptoStorePath, prefix, "CRYPTO_STORE_PATH")
+	for i := range c.Peers {
+		overrideFromEnv(&c.Peers[i].URL, prefix, "PEER", envName(c.Peers[i].Name), "URL")
+		overrideFromEnv(&c.Peers[i].TLSCACertPath, prefix, "PEER", envName(c.Peers[i].Name), "TLS_CA_CERT_PATH")
+	}
+	for i := range c.Orderers {
+		overrideFromEnv(&c.Orderers[i].URL, prefix, "ORDERER", envName(c.Orderers[i].Name), "URL")
+		overrideFromEnv(&c.Orderers[i].TLSCACertPath, prefix, "ORDERER", envName(c.Orderers[i].Name), "TLS_CA_CERT_PATH")
+	}
+	return c
+}
+
+// Validate checks that client organization, peers of organizations and channels are declared and that peers and orderers have URLs
+func (c *Config) Validate() error {
+	if c.Organization == "" {
+		return fmt.Errorf("Organization of client is not set in config")
+	}
+	peers, err := declaredNodes("peer", c.Peers)
+	if err != nil {
+		return err
+	}
+	if _, err = declaredNodes("orderer", c.Orderers); err != nil {
+		return err
+	}
+	organizations := map[string]bool{}
+	for _, organization := range c.Organizations {
+		if organization.Name == "" || organization.MSPID == "" {
+			return fmt.Errorf("Organization %q must have name and MSP ID", organization.Name)
+		}
+		if organizations[organization.Name] {
+			return fmt.Errorf("Organization %s is declared more than once", organization.Name)
+		}
+		organizations[organization.Name] = true
+		for _, peer := range organization.Peers {
+			if !peers[peer] {
+				return fmt.Errorf("Peer %s of organization %s is not declared in peers", peer, organization.Name)
+			}
+		}
+	}
+	if !organizations[c.Organization] {
+		return fmt.Errorf("Organization of client %s is not declared in organizations", c.Organization)
+	}
+	for _, channel := range c.Channels {
+		if channel.ID == "" {
+			return fmt.Errorf("Channel id is not set in config")
+		}
+		for _, peer := range channel.Peers {
+			if !peers[peer] {
+				return fmt.Errorf("Peer %s of channel %s is not declared in peers", peer, channel.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// declaredNodes checks that nodes have unique names and URLs and returns set of their names
+func declaredNodes(kind string, nodes []NodeConfig) (map[string]bool, error) {
+	names := map[string]bool{}
+	for _, node := range nodes {
+		if node.Name == "" {
+			return nil, fmt.Errorf("Name of %s with URL %q is not set in config", kind, node.URL)
+		}
+		if node.URL == "" {
+			return nil, fmt.Errorf("URL of %s %s is not set in config", kind, node.Name)
+		}
+		if names[node.Name] {
+			return nil, fmt.Errorf("Config declares %s %s more than once", kind, node.Name)
+		}
+		names[node.Name] = true
+	}
+	return names, nil
+}
+
+// ConfigProvider validates config and converts it to fabric-sdk-go config provider
+func (c *Config) ConfigProvider() (core.ConfigProvider, error) {
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("Invalid fabric client config.\n Error: %w", err)
+	}
+	raw, err := json.Marshal(c.sdkConfig())
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal fabric SDK config.\n Error: %w", err)
+	}
+	return config.FromRaw(raw, "json"), nil
+}
+
+// sdkConfig returns config in format of fabric-sdk-go config file
+func (c *Config) sdkConfig() map[string]interface{} {
+	client := map[string]interface{}{
+		"organization": c.Organization,
+		"BCCSP": map[string]interface{}{
+			"security": map[string]interface{}{
+				"enabled":       true,
+				"default":       map[string]interface{}{"provider": "SW"},
+				"hashAlgorithm": "SHA2",
+				"softVerify":    true,
+				"level":         256,
+			},
+		},
+	}
+	if c.LogLevel != "" {
+		client["logging"] = map[string]interface{}{"level": c.LogLevel}
+	}
+	if c.CryptoConfigPath != "" {
+		client["cryptoconfig"] = map[string]interface{}{"path": c.CryptoConfigPath}
+	}
+	if c.CredentialStorePath != "" || c.CryptoStorePath != "" {
+		client["credentialStore"] = map[string]interface{}{
+			"path":        c.CredentialStorePath,
+			"cryptoStore": map[string]interface{}{"path": c.CryptoStorePath},
+		}
+	}
+
+	organizations := map[string]interface{}{}
+	for _, organization := range c.Organizations {
+		users := map[string]interface{}{}
+		for _, user := range organization.Users {
+			users[user.Name] = map[string]interface{}{
+				"cert": map[string]interface{}{"pem": user.CertPEM},
+				"key":  map[string]interface{}{"pem": user.KeyPEM},
+			}
+		}
+		organizations[organization.Name] = map[string]interface{}{
+			"mspid":      organization.MSPID,
+			"cryptoPath": organization.CryptoPath,
+			"peers":      organization.Peers,
+			"users":      users,
+		}
+	}
+
+	channels := map[string]interface{}{}
+	for _, channel := range c.Channels {
+		peers := map[string]interface{}{}
+		for _, peer := range channel.Peers {
+			peers[peer] = map[string]interface{}{
+				"endorsingPeer":  true,
+				"chaincodeQuery": true,
+				"ledgerQuery":    true,
+				"eventSource":    true,
+			}
+		}
+		channels[channel.ID] = map[string]interface{}{"peers": peers}
+	}
+
+	return map[string]interface{}{
+		"version":       "1.0.0",
+		"client":        client,
+		"organizations": organizations,
+		"peers":         nodesConfig(c.Peers),
+		"orderers":      nodesConfig(c.Orderers),
+		"channels":      channels,
+	}
+}
+
+func nodesConfig(nodes []NodeConfig) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, node := range nodes {
+		grpcOptions := map[string]interface{}{"allow-insecure": node.AllowInsecure, "fail-fast": false}
+		if node.SSLTargetNameOverride != "" {
+			grpcOptions["ssl-target-name-override"] = node.SSLTargetNameOverride
+		}
+		nodeConfig := map[string]interface{}{
+			"url":         node.URL,
+			"grpcOptions": grpcOptions,
+		}
+		if node.TLSCACertPath != "" || node.TLSCACertPEM != "" {
+			nodeConfig["tlsCACerts"] = map[string]interface{}{"path": node.TLSCACertPath, "pem": node.TLSCACertPEM}
+		}
+		result[node.Name] = nodeConfig
+	}
+	return result
+}
+
+func overrideFromEnv(target *string, parts ...string) {
+	if value, ok := os.LookupEnv(strings.Join(parts, "_")); ok {
+		*target = value
+	}
+}
+
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+}
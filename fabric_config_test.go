@@ -0,0 +1,144 @@
+package fabclient
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+const testYAMLConfig = `
+organization: Org1
+organizations:
+  - name: Org1
+    mspID: Org1MSP
+    cryptoPath: peerOrganizations/org1/users/{username}/msp
+    peers: [peer0.org1]
+peers:
+  - name: peer0.org1
+    url: grpcs://localhost:7051
+    tlsCACertPath: /tls/peer.pem
+orderers:
+  - name: orderer0
+    url: grpcs://localhost:7050
+channels:
+  - id: mychannel
+    peers: [peer0.org1]
+`
+
+const testJSONConfig = `{
+  "organization": "Org1",
+  "organizations": [{"name": "Org1", "mspID": "Org1MSP", "cryptoPath": "peerOrganizations/org1/users/{username}/msp", "peers": ["peer0.org1"]}],
+  "peers": [{"name": "peer0.org1", "url": "grpcs://localhost:7051", "tlsCACertPath": "/tls/peer.pem"}],
+  "orderers": [{"name": "orderer0", "url": "grpcs://localhost:7050"}],
+  "channels": [{"id": "mychannel", "peers": ["peer0.org1"]}]
+}`
+
+func testConfig() *Config {
+	return CreateConfig("Org1").
+		AddOrganization(OrganizationConfig{Name: "Org1", MSPID: "Org1MSP", CryptoPath: "peerOrganizations/org1/users/{username}/msp", Peers: []string{"peer0.org1"}}).
+		AddPeer(NodeConfig{Name: "peer0.org1", URL: "grpcs://localhost:7051", TLSCACertPath: "/tls/peer.pem"}).
+		AddOrderer(NodeConfig{Name: "orderer0", URL: "grpcs://localhost:7050"}).
+		AddChannel(ChannelConfig{ID: "mychannel", Peers: []string{"peer0.org1"}})
+}
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        string
+		configType string
+		fails      bool
+	}{
+		{name: "yaml", raw: testYAMLConfig, configType: "yaml"},
+		{name: "yml", raw: testYAMLConfig, configType: "YML"},
+		{name: "json", raw: testJSONConfig, configType: "json"},
+		{name: "unknown type", raw: testJSONConfig, configType: "toml", fails: true},
+		{name: "malformed json", raw: "{", configType: "json", fails: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := ParseConfig([]byte(test.raw), test.configType)
+			if test.fails {
+				if err == nil {
+					t.Fatal("error is expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cfg, testConfig()) {
+				t.Fatalf("parsed config %+v, expected %+v", cfg, testConfig())
+			}
+			if err = cfg.Validate(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestOverrideFromEnv(t *testing.T) {
+	variables := map[string]string{
+		"FABRIC_ORGANIZATION":                     "Org2",
+		"FABRIC_PEER_PEER0_ORG1_URL":              "grpcs://peer0:7051",
+		"FABRIC_PEER_PEER0_ORG1_TLS_CA_CERT_PATH": "/override/peer.pem",
+		"FABRIC_ORDERER_ORDERER0_URL":             "grpcs://orderer0:7050",
+		"OTHER_LOG_LEVEL":                         "debug",
+	}
+	for name, value := range variables {
+		os.Setenv(name, value)
+		defer os.Unsetenv(name)
+	}
+	cfg := testConfig().OverrideFromEnv("FABRIC")
+	tests := []struct {
+		name     string
+		actual   string
+		expected string
+	}{
+		{name: "organization", actual: cfg.Organization, expected: "Org2"},
+		{name: "peer url", actual: cfg.Peers[0].URL, expected: "grpcs://peer0:7051"},
+		{name: "peer tls certificate", actual: cfg.Peers[0].TLSCACertPath, expected: "/override/peer.pem"},
+		{name: "orderer url", actual: cfg.Orderers[0].URL, expected: "grpcs://orderer0:7050"},
+		{name: "variable with other prefix is ignored", actual: cfg.LogLevel, expected: ""},
+		{name: "value without variable is kept", actual: cfg.Channels[0].ID, expected: "mychannel"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.actual != test.expected {
+				t.Fatalf("value is %q, expected %q", test.actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		fails  bool
+	}{
+		{name: "valid config", modify: func(*Config) {}},
+		{name: "client organization is not set", modify: func(c *Config) { c.Organization = "" }, fails: true},
+		{name: "client organization is not declared", modify: func(c *Config) { c.Organization = "Org2" }, fails: true},
+		{name: "organization without MSP ID", modify: func(c *Config) { c.Organizations[0].MSPID = "" }, fails: true},
+		{name: "organization peer is not declared", modify: func(c *Config) { c.Organizations[0].Peers = []string{"peer1.org1"} }, fails: true},
+		{name: "channel peer is not declared", modify: func(c *Config) { c.Channels[0].Peers = []string{"peer1.org1"} }, fails: true},
+		{name: "channel without id", modify: func(c *Config) { c.Channels[0].ID = "" }, fails: true},
+		{name: "peer without url", modify: func(c *Config) { c.Peers[0].URL = "" }, fails: true},
+		{name: "peer without name", modify: func(c *Config) { c.Peers[0].Name = "" }, fails: true},
+		{name: "duplicated peer", modify: func(c *Config) { c.AddPeer(c.Peers[0]) }, fails: true},
+		{name: "orderer without url", modify: func(c *Config) { c.Orderers[0].URL = "" }, fails: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := testConfig()
+			test.modify(cfg)
+			err := cfg.Validate()
+			if test.fails != (err != nil) {
+				t.Fatalf("unexpected validation result %v", err)
+			}
+			if _, providerErr := cfg.ConfigProvider(); test.fails != (providerErr != nil) {
+				t.Fatalf("config provider is created for invalid config: %v", providerErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+module github.com/halfest/fabric-client
+
+go 1.14
+
+require (
+	github.com/golang/protobuf v1.3.3
+	github.com/hyperledger/fabric-protos-go v0.0.0-20200707132912-fee30f3ccd23
+	github.com/hyperledger/fabric-sdk-go v1.0.0
+	github.com/pkg/errors v0.9.1
+	golang.org/x/net v0.0.0-20190620200207-3b0461eec859 // indirect
+	google.golang.org/grpc v1.29.1
+	gopkg.in/yaml.v2 v2.3.0
+)
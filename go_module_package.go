@@ -0,0 +1,196 @@
+package fabclient
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// goPackageFiles are files which are included to Go chaincode package regardless of extension so module metadata is kept
+var goPackageFiles = map[string]bool{
+	"go.mod":      true,
+	"go.sum":      true,
+	"modules.txt": true,
+}
+
+// goModuleInfo describes Go module which contains chaincode
+type goModuleInfo struct {
+	dir        string
+	path       string
+	importPath string
+}
+
+// findGoModule looks for go.mod in chaincode directory or its parents. nil is returned if chaincodePath is not a directory
+// or no go.mod is found above it, so it is treated as import path inside GOPATH
+func findGoModule(chaincodePath string) (*goModuleInfo, error) {
+	chaincodeDir, err := filepath.Abs(chaincodePath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get absolute path of %s.\n Error: %w", chaincodePath, err)
+	}
+	if info, statErr := os.Stat(chaincodeDir); statErr != nil || !info.IsDir() {
+		return nil, nil
+	}
+	moduleDir := chaincodeDir
+	for {
+		if _, statErr := os.Stat(filepath.Join(moduleDir, "go.mod")); statErr == nil {
+			break
+		}
+		if filepath.Dir(moduleDir) == moduleDir {
+			return nil, nil
+		}
+		moduleDir = filepath.Dir(moduleDir)
+	}
+	modulePath, err := readModulePath(filepath.Join(moduleDir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	relativePath, err := filepath.Rel(moduleDir, chaincodeDir)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get path of %s inside module %s.\n Error: %w", chaincodeDir, moduleDir, err)
+	}
+	return &goModuleInfo{
+		dir:        moduleDir,
+		path:       modulePath,
+		importPath: path.Join(modulePath, filepath.ToSlash(relativePath)),
+	}, nil
+}
+
+func readModulePath(goModPath string) (string, error) {
+	goMod, err := os.Open(goModPath)
+	if err != nil {
+		return "", fmt.Errorf("Failed to open %s.\n Error: %w", goModPath, err)
+	}
+	defer goMod.Close()
+	scanner := bufio.NewScanner(goMod)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "module") {
+			continue
+		}
+		modulePath := strings.TrimSpace(strings.TrimPrefix(line, "module"))
+		if unquoted, err := strconv.Unquote(modulePath); err == nil {
+			modulePath = unquoted
+		}
+		if modulePath != "" {
+			return modulePath, nil
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", fmt.Errorf("Failed to read %s.\n Error: %w", goModPath, err)
+	}
+	return "", fmt.Errorf("%s does not contain module directive", goModPath)
+}
+
+// packageGoModule packs module with its vendor directory as src/<module path> entries in .tar.gz format, so peer builds
+// chaincode with vendored dependencies and without access to module proxy. If module has no vendor directory it is vendored
+// in temporary copy of module from local module cache. META-INF directory of chaincode is packed to root of package
+func packageGoModule(moduleDir string, modulePath string, chaincodeDir string) ([]byte, error) {
+	metadataEntries, err := readChaincodeMetadataDir(chaincodeDir)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(moduleDir, "vendor")); os.IsNotExist(err) {
+		vendoredDir, err := vendorGoModule(moduleDir)
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(vendoredDir)
+		moduleDir = vendoredDir
+	}
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+	err = filepath.Walk(moduleDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if filePath != moduleDir && (strings.HasPrefix(info.Name(), ".") || info.Name()+"/" == metadataPrefix) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() || !(goPackageFileTypes[filepath.Ext(filePath)] || goPackageFiles[info.Name()]) {
+			return nil
+		}
+		relativePath, err := filepath.Rel(moduleDir, filePath)
+		if err != nil {
+			return err
+		}
+		return writeFileToPackage(tw, filePath, path.Join("src", modulePath, filepath.ToSlash(relativePath)))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to pack Go module %s from %s.\n Error: %w", modulePath, moduleDir, err)
+	}
+	for _, entry := range metadataEntries {
+		if err = tw.WriteHeader(packageFileHeader(entry.name, int64(len(entry.content)))); err == nil {
+			_, err = tw.Write(entry.content)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Failed to pack metadata of Go module %s.\n Error: %w", modulePath, err)
+		}
+	}
+	if err = tw.Close(); err == nil {
+		err = gw.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to finish package of Go module %s.\n Error: %w", modulePath, err)
+	}
+	return payload.Bytes(), nil
+}
+
+// vendorGoModule copies module to temporary directory and runs go mod vendor there so directory of user is not modified
+func vendorGoModule(moduleDir string) (string, error) {
+	tmpDir, err := ioutil.TempDir("", "fabclient-chaincode")
+	if err != nil {
+		return "", fmt.Errorf("Failed to create temporary directory for vendoring %s.\n Error: %w", moduleDir, err)
+	}
+	if err = copyDir(moduleDir, tmpDir); err != nil {
+		os.RemoveAll(tmpDir)
+		return "", fmt.Errorf("Failed to copy module %s for vendoring.\n Error: %w", moduleDir, err)
+	}
+	cmd := exec.Command("go", "mod", "vendor")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		os.RemoveAll(tmpDir)
+		return "", fmt.Errorf("Failed to vendor dependencies of module %s.\n Error: %w\n Output: %s", moduleDir, err, output)
+	}
+	logger.Debugf("Dependencies of module %s vendored", moduleDir)
+	return tmpDir, nil
+}
+
+func copyDir(sourceDir string, targetDir string) error {
+	return filepath.Walk(sourceDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(sourceDir, filePath)
+		if err != nil {
+			return err
+		}
+		targetPath := filepath.Join(targetDir, relativePath)
+		if info.IsDir() {
+			if filePath != sourceDir && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(targetPath, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(targetPath, content, 0644)
+	})
+}
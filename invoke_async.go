@@ -0,0 +1,201 @@
+package fabclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+)
+
+// InvokeHandle tracks commit of transaction sent by InvokeAsync
+type InvokeHandle struct {
+	txID    string
+	done    chan struct{}
+	receipt *Receipt
+	err     error
+}
+
+// TxID returns id of transaction
+func (h *InvokeHandle) TxID() string {
+	return h.txID
+}
+
+// Done returns channel which is closed when transaction is committed or commit can not be awaited anymore
+func (h *InvokeHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait blocks until transaction is committed and returns its receipt. If ctx is done earlier ctx.Err() is returned and transaction can be awaited again
+func (h *InvokeHandle) Wait(ctx context.Context) (*Receipt, error) {
+	select {
+	case <-h.done:
+		return h.receipt, h.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (h *InvokeHandle) finish(receipt *Receipt, err error) {
+	h.receipt = receipt
+	h.err = err
+	close(h.done)
+}
+
+// submitHandler sends endorsed transaction to orderer without waiting for commit.
+// Commit status is registered before sending so notification can not be missed
+type submitHandler struct {
+	events     *txStatusEvents
+	lock       sync.Mutex
+	abandoned  bool
+	unregister func()
+	statuses   <-chan *fab.TxStatusEvent
+}
+
+func (h *submitHandler) Handle(requestContext *invoke.RequestContext, clientContext *invoke.ClientContext) {
+	txID := string(requestContext.Response.TransactionID)
+	statuses, unregister, err := h.events.register(txID)
+	if err != nil {
+		requestContext.Error = err
+		return
+	}
+	tx, err := clientContext.Transactor.CreateTransaction(fab.TransactionRequest{
+		Proposal:          requestContext.Response.Proposal,
+		ProposalResponses: requestContext.Response.Responses,
+	})
+	if err == nil {
+		_, err = clientContext.Transactor.SendTransaction(tx)
+	}
+	if err != nil {
+		unregister()
+		requestContext.Error = fmt.Errorf("Failed to send transaction %s to orderer.\n Error: %w", txID, err)
+		return
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.abandoned {
+		// request is already failed, so commit of transaction is not awaited
+		unregister()
+		return
+	}
+	h.unregister = unregister
+	h.statuses = statuses
+}
+
+// abandon unregisters commit status of transaction if request failed. Handler may still be running after request is timed out,
+// so status is unregistered when handler completes
+func (h *submitHandler) abandon() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.abandoned = true
+	if h.unregister != nil {
+		h.unregister()
+		h.unregister = nil
+	}
+}
+
+// InvokeAsync is the same as Invoke but returns as soon as transaction is sent to orderer.
+// Commit is awaited with returned handle. Transactions invalidated at commit are not resubmitted
+func (c *UserClient) InvokeAsync(chaincodeID string, functionName string, args [][]byte, opts ...Option) (*InvokeHandle, error) {
+	return c.InvokeAsyncContext(context.Background(), chaincodeID, functionName, args, opts...)
+}
+
+// InvokeAsyncContext is the same as InvokeAsync but endorsement and sending to orderer are bound to ctx. Commit is awaited regardless of ctx
+// until commit timeout, zero commit timeout means execute timeout from fabric-sdk-go config
+func (c *UserClient) InvokeAsyncContext(ctx context.Context, chaincodeID string, functionName string, args [][]byte, opts ...Option) (*InvokeHandle, error) {
+	options := c.options.with(opts)
+	commitTimeout := options.commitTimeout
+	if commitTimeout <= 0 {
+		var err error
+		if commitTimeout, err = c.defaultTimeout(fab.Execute); err != nil {
+			return nil, err
+		}
+	}
+	var resp channel.Response
+	submit := &submitHandler{}
+	// commit is awaited outside of in-flight request, so graceful close does not wait for it
+	err := c.fabricClient.do(ctx, func() error {
+		events, err := c.fabricClient.txStatusEvents(c.channelID, c.channelProvider)
+		if err != nil {
+			return err
+		}
+		submit.events = events
+		handler := invoke.NewSelectAndEndorseHandler(
+			invoke.NewEndorsementValidationHandler(
+				invoke.NewSignatureValidationHandler(submit),
+			),
+		)
+		resp, err = c.channelClient.InvokeHandler(handler, channel.Request{ChaincodeID: chaincodeID, Fcn: functionName, Args: args}, options.executeOptions(ctx)...)
+		if err != nil {
+			submit.abandon()
+			return fmt.Errorf("Failed to invoke chaincode %s with function %s and arguments %v.\n Error: %w", chaincodeID, functionName, args, classifyError(chaincodeID, string(resp.TransactionID), err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	handle := &InvokeHandle{txID: string(resp.TransactionID), done: make(chan struct{})}
+	go func() {
+		defer submit.unregister()
+		handle.finish(c.awaitCommit(committedResponse{Response: resp}, submit.statuses, commitTimeout))
+	}()
+	logger.Debugf("Transaction %s sent to orderer", handle.txID)
+	return handle, nil
+}
+
+// defaultTimeout returns timeout of timeoutType from fabric-sdk-go config
+func (c *UserClient) defaultTimeout(timeoutType fab.TimeoutType) (time.Duration, error) {
+	channelContext, err := c.channelProvider()
+	if err != nil {
+		return 0, fmt.Errorf("Failed to get context of channel %s.\n Error: %w", c.channelID, err)
+	}
+	return channelContext.EndpointConfig().Timeout(timeoutType), nil
+}
+
+// awaitCommit waits for status of transaction until timeout. Zero timeout means waiting until Fabric Client is closed
+func (c *UserClient) awaitCommit(resp committedResponse, statuses <-chan *fab.TxStatusEvent, timeout time.Duration) (*Receipt, error) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case status, ok := <-statuses:
+		if !ok {
+			return nil, fmt.Errorf("Event connection is closed before commit of transaction %s", resp.TransactionID)
+		}
+		resp.TxValidationCode = status.TxValidationCode
+		resp.BlockNumber = status.BlockNumber
+	case <-expired:
+		return nil, &TimeoutError{Message: fmt.Sprintf("transaction %s is not committed in %s", resp.TransactionID, timeout)}
+	case <-c.fabricClient.shutdown:
+		return nil, ErrClosed
+	}
+	if resp.TxValidationCode != pb.TxValidationCode_VALID {
+		return nil, fmt.Errorf("Failed to commit transaction %s.\n Error: %w", resp.TransactionID, &TransactionError{TxID: string(resp.TransactionID), ValidationCode: resp.TxValidationCode})
+	}
+	receipt, err := newReceipt(resp)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create receipt for transaction %s.\n Error: %w", resp.TransactionID, err)
+	}
+	receipt.Attempts = 1
+	logger.Debugf("Transaction %s committed in block %d", receipt.TxID, receipt.BlockNumber)
+	return receipt, nil
+}
+
+// InvokeAsync is the same as Invoke but returns as soon as transaction is sent to orderer.
+// Commit is awaited with returned handle. Transactions invalidated at commit are not resubmitted
+func (c *ChaincodeClient) InvokeAsync(functionName string, args [][]byte, opts ...Option) (*InvokeHandle, error) {
+	return c.InvokeAsyncContext(context.Background(), functionName, args, opts...)
+}
+
+// InvokeAsyncContext is the same as InvokeAsync but endorsement and sending to orderer are bound to ctx. Commit is awaited regardless of ctx
+func (c *ChaincodeClient) InvokeAsyncContext(ctx context.Context, functionName string, args [][]byte, opts ...Option) (*InvokeHandle, error) {
+	return c.userClient.InvokeAsyncContext(ctx, c.chaincodeID, functionName, args, opts...)
+}
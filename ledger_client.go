@@ -0,0 +1,175 @@
+package fabclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/ledger"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+)
+
+// LedgerClient is used to query blocks, transactions and configuration from channel ledger
+type LedgerClient struct {
+	name         string
+	organization string
+	channelID    string
+	ledgerClient *ledger.Client
+	fabricClient *FabricClient
+}
+
+// ChainInfo contains information about channel blockchain
+type ChainInfo struct {
+	Height            uint64
+	CurrentBlockHash  []byte
+	PreviousBlockHash []byte
+	Endorser          string
+}
+
+// CreateLedgerClient is the same as  (c *FabricClient) CreateLedgerClient(channelID string, name string, organization string) but it does not reuse Fabric Client
+func CreateLedgerClient(configPath string, ordererHost string, channelID string, name string, organization string) (*LedgerClient, error) {
+	fabricClient, err := CreateFabricClient(configPath, ordererHost)
+	if err != nil {
+		return nil, err
+	}
+	return fabricClient.CreateLedgerClient(channelID, name, organization)
+}
+
+// CreateLedgerClient creates new Ledger Client
+func (c *FabricClient) CreateLedgerClient(channelID string, name string, organization string) (*LedgerClient, error) {
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
+	channelProvider := c.sdk.ChannelContext(channelID, fabsdk.WithUser(name), fabsdk.WithOrg(organization))
+	clientInstance, err := ledger.New(channelProvider)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create ledger client with channel id %s, user name %s and organization %s.\n Error: %w", channelID, name, organization, err)
+	}
+	logger.Debugf("Ledger client for channelID: %s, user: %s and organization: %s created", channelID, name, organization)
+	return &LedgerClient{
+		name:         name,
+		organization: organization,
+		channelID:    channelID,
+		ledgerClient: clientInstance,
+		fabricClient: c,
+	}, nil
+}
+
+// QueryInfo queries height and hashes of current and previous blocks of channel
+func (c *LedgerClient) QueryInfo() (*ChainInfo, error) {
+	return c.QueryInfoContext(context.Background())
+}
+
+// QueryInfoContext is the same as QueryInfo but request is bound to ctx
+func (c *LedgerClient) QueryInfoContext(ctx context.Context) (*ChainInfo, error) {
+	var info *ChainInfo
+	err := c.fabricClient.do(ctx, func() error {
+		resp, err := c.ledgerClient.QueryInfo(ledger.WithParentContext(ctx))
+		if err != nil {
+			return fmt.Errorf("Failed to query info of channel %s.\n Error: %w", c.channelID, err)
+		}
+		info = &ChainInfo{
+			Height:            resp.BCI.Height,
+			CurrentBlockHash:  resp.BCI.CurrentBlockHash,
+			PreviousBlockHash: resp.BCI.PreviousBlockHash,
+			Endorser:          resp.Endorser,
+		}
+		return nil
+	})
+	return info, err
+}
+
+// QueryBlock queries block by its number
+func (c *LedgerClient) QueryBlock(blockNumber uint64) (*common.Block, error) {
+	return c.QueryBlockContext(context.Background(), blockNumber)
+}
+
+// QueryBlockContext is the same as QueryBlock but request is bound to ctx
+func (c *LedgerClient) QueryBlockContext(ctx context.Context, blockNumber uint64) (*common.Block, error) {
+	var block *common.Block
+	err := c.fabricClient.do(ctx, func() (err error) {
+		if block, err = c.ledgerClient.QueryBlock(blockNumber, ledger.WithParentContext(ctx)); err != nil {
+			return fmt.Errorf("Failed to query block %d of channel %s.\n Error: %w", blockNumber, c.channelID, err)
+		}
+		return nil
+	})
+	return block, err
+}
+
+// QueryBlockByHash queries block by its hash
+func (c *LedgerClient) QueryBlockByHash(blockHash []byte) (*common.Block, error) {
+	return c.QueryBlockByHashContext(context.Background(), blockHash)
+}
+
+// QueryBlockByHashContext is the same as QueryBlockByHash but request is bound to ctx
+func (c *LedgerClient) QueryBlockByHashContext(ctx context.Context, blockHash []byte) (*common.Block, error) {
+	var block *common.Block
+	err := c.fabricClient.do(ctx, func() (err error) {
+		if block, err = c.ledgerClient.QueryBlockByHash(blockHash, ledger.WithParentContext(ctx)); err != nil {
+			return fmt.Errorf("Failed to query block with hash %x of channel %s.\n Error: %w", blockHash, c.channelID, err)
+		}
+		return nil
+	})
+	return block, err
+}
+
+// QueryBlockByTxID queries block which contains transaction
+func (c *LedgerClient) QueryBlockByTxID(txID string) (*common.Block, error) {
+	return c.QueryBlockByTxIDContext(context.Background(), txID)
+}
+
+// QueryBlockByTxIDContext is the same as QueryBlockByTxID but request is bound to ctx
+func (c *LedgerClient) QueryBlockByTxIDContext(ctx context.Context, txID string) (*common.Block, error) {
+	var block *common.Block
+	err := c.fabricClient.do(ctx, func() (err error) {
+		block, err = c.ledgerClient.QueryBlockByTxID(fab.TransactionID(txID), ledger.WithParentContext(ctx))
+		if err != nil && isTransactionNotFound(err) {
+			return fmt.Errorf("Failed to query block with transaction %s of channel %s.\n Error: %v: %w", txID, c.channelID, err, ErrTransactionNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to query block with transaction %s of channel %s.\n Error: %w", txID, c.channelID, err)
+		}
+		return nil
+	})
+	return block, err
+}
+
+// QueryTransaction queries transaction envelope and its validation code
+func (c *LedgerClient) QueryTransaction(txID string) (*pb.ProcessedTransaction, error) {
+	return c.QueryTransactionContext(context.Background(), txID)
+}
+
+// QueryTransactionContext is the same as QueryTransaction but request is bound to ctx
+func (c *LedgerClient) QueryTransactionContext(ctx context.Context, txID string) (*pb.ProcessedTransaction, error) {
+	var transaction *pb.ProcessedTransaction
+	err := c.fabricClient.do(ctx, func() (err error) {
+		transaction, err = c.ledgerClient.QueryTransaction(fab.TransactionID(txID), ledger.WithParentContext(ctx))
+		if err != nil && isTransactionNotFound(err) {
+			return fmt.Errorf("Failed to query transaction %s of channel %s.\n Error: %v: %w", txID, c.channelID, err, ErrTransactionNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to query transaction %s of channel %s.\n Error: %w", txID, c.channelID, err)
+		}
+		return nil
+	})
+	return transaction, err
+}
+
+// QueryConfig queries current configuration of channel
+func (c *LedgerClient) QueryConfig() (fab.ChannelCfg, error) {
+	return c.QueryConfigContext(context.Background())
+}
+
+// QueryConfigContext is the same as QueryConfig but request is bound to ctx
+func (c *LedgerClient) QueryConfigContext(ctx context.Context) (fab.ChannelCfg, error) {
+	var channelConfig fab.ChannelCfg
+	err := c.fabricClient.do(ctx, func() (err error) {
+		if channelConfig, err = c.ledgerClient.QueryConfig(ledger.WithParentContext(ctx)); err != nil {
+			return fmt.Errorf("Failed to query config of channel %s.\n Error: %w", c.channelID, err)
+		}
+		return nil
+	})
+	return channelConfig, err
+}
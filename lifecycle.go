@@ -0,0 +1,317 @@
+package fabclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	lcpackager "github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/lifecycle"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/policydsl"
+)
+
+// InstalledChaincode is chaincode package installed on peer with Fabric 2.x lifecycle
+type InstalledChaincode struct {
+	PackageID string
+	Label     string
+	// References maps channel id to chaincodes which use package on that channel
+	References map[string][]string
+}
+
+// CommittedChaincode is chaincode definition committed on channel with Fabric 2.x lifecycle
+type CommittedChaincode struct {
+	Name                string
+	Version             string
+	Sequence            int64
+	InitRequired        bool
+	EndorsementPlugin   string
+	ValidationPlugin    string
+	ChannelConfigPolicy string
+	Approvals           map[string]bool
+}
+
+// CreateLifecycleChaincodeParameters used to construct ChaincodeParameters for Fabric 2.x lifecycle
+func CreateLifecycleChaincodeParameters(chaincodeID string, chaincodePath string, version string, label string, sequence int64, policy string, initRequired bool) *ChaincodeParameters {
+	return &ChaincodeParameters{
+		ChaincodeID:   chaincodeID,
+		ChaincodePath: chaincodePath,
+		Version:       version,
+		Policy:        policy,
+		Label:         label,
+		Sequence:      sequence,
+		InitRequired:  initRequired,
+	}
+}
+
+// LifecyclePackageChaincode creates Fabric 2.x chaincode package with label from chaincodeParameters
+func (c *ConfigurationClient) LifecyclePackageChaincode(chaincodeParameters *ChaincodeParameters) ([]byte, error) {
+	ccType, err := chaincodeParameters.Language.chaincodeType()
+	if err != nil {
+		return nil, err
+	}
+	switch ccType {
+	case pb.ChaincodeSpec_NODE:
+		err = validateNodeProject(chaincodeParameters.ChaincodePath)
+	case pb.ChaincodeSpec_JAVA:
+		err = validateJavaProject(chaincodeParameters.ChaincodePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ccPkg, err := lcpackager.NewCCPackage(&lcpackager.Descriptor{
+		Path:  chaincodeParameters.ChaincodePath,
+		Type:  ccType,
+		Label: chaincodeParameters.Label,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create chaincode package with chaincode path %s and label %s.\n Error: %w", chaincodeParameters.ChaincodePath, chaincodeParameters.Label, err)
+	}
+	if chaincodeParameters.MetadataPath != "" {
+		return addMetadataToLifecyclePackage(ccPkg, chaincodeParameters.MetadataPath)
+	}
+	return ccPkg, nil
+}
+
+// LifecycleInstallChaincode packages and installs chaincode on peers of organization with Fabric 2.x lifecycle. Package id is returned
+func (c *ConfigurationClient) LifecycleInstallChaincode(chaincodeParameters *ChaincodeParameters) (string, error) {
+	return c.LifecycleInstallChaincodeContext(context.Background(), chaincodeParameters)
+}
+
+// LifecycleInstallChaincodeContext is the same as LifecycleInstallChaincode but request is bound to ctx
+func (c *ConfigurationClient) LifecycleInstallChaincodeContext(ctx context.Context, chaincodeParameters *ChaincodeParameters) (string, error) {
+	var packageID string
+	err := c.fabricClient.do(ctx, func() error {
+		ccPkg, err := c.LifecyclePackageChaincode(chaincodeParameters)
+		if err != nil {
+			return err
+		}
+		_, err = c.resMgmtClient.LifecycleInstallCC(resmgmt.LifecycleInstallCCRequest{Label: chaincodeParameters.Label, Package: ccPkg},
+			c.options.resMgmtOptions(ctx)...)
+		if err != nil {
+			return fmt.Errorf("Failed to install chaincode package with label %s.\n Error: %w", chaincodeParameters.Label, classifyError(chaincodeParameters.ChaincodeID, "", err))
+		}
+		packageID = lcpackager.ComputePackageID(chaincodeParameters.Label, ccPkg)
+		logger.Debugf("Chaincode package %s installed", packageID)
+		return nil
+	})
+	return packageID, err
+}
+
+// LifecycleQueryInstalledChaincodes queries chaincode packages installed on peers of organization
+func (c *ConfigurationClient) LifecycleQueryInstalledChaincodes() ([]InstalledChaincode, error) {
+	return c.LifecycleQueryInstalledChaincodesContext(context.Background())
+}
+
+// LifecycleQueryInstalledChaincodesContext is the same as LifecycleQueryInstalledChaincodes but request is bound to ctx
+func (c *ConfigurationClient) LifecycleQueryInstalledChaincodesContext(ctx context.Context) ([]InstalledChaincode, error) {
+	var installed []InstalledChaincode
+	err := c.fabricClient.do(ctx, func() error {
+		resp, err := c.resMgmtClient.LifecycleQueryInstalledCC(c.options.resMgmtOptions(ctx)...)
+		if err != nil {
+			return fmt.Errorf("Failed to query installed chaincodes.\n Error: %w", classifyError("", "", err))
+		}
+		installed = make([]InstalledChaincode, 0, len(resp))
+		for _, chaincode := range resp {
+			references := map[string][]string{}
+			for channelID, chaincodes := range chaincode.References {
+				for _, reference := range chaincodes {
+					references[channelID] = append(references[channelID], reference.Name)
+				}
+			}
+			installed = append(installed, InstalledChaincode{PackageID: chaincode.PackageID, Label: chaincode.Label, References: references})
+		}
+		return nil
+	})
+	return installed, err
+}
+
+// LifecycleApproveChaincode approves chaincode definition for organization of configuration client. PackageID must be set in chaincodeParameters
+func (c *ConfigurationClient) LifecycleApproveChaincode(channelID string, chaincodeParameters *ChaincodeParameters) error {
+	return c.LifecycleApproveChaincodeContext(context.Background(), channelID, chaincodeParameters)
+}
+
+// LifecycleApproveChaincodeContext is the same as LifecycleApproveChaincode but request is bound to ctx
+func (c *ConfigurationClient) LifecycleApproveChaincodeContext(ctx context.Context, channelID string, chaincodeParameters *ChaincodeParameters) error {
+	_, err := c.LifecycleApproveChaincodeWithOrderer(ctx, channelID, chaincodeParameters)
+	return err
+}
+
+// LifecycleApproveChaincodeWithOrderer is the same as LifecycleApproveChaincodeContext but also returns endpoint of orderer which served approval transaction.
+// Endpoint is empty if orderer is taken from channel config
+func (c *ConfigurationClient) LifecycleApproveChaincodeWithOrderer(ctx context.Context, channelID string, chaincodeParameters *ChaincodeParameters) (string, error) {
+	var ordererEndpoint string
+	err := c.fabricClient.do(ctx, func() error {
+		if chaincodeParameters.PackageID == "" {
+			return fmt.Errorf("Failed to approve chaincode %s version %s: package id is empty", chaincodeParameters.ChaincodeID, chaincodeParameters.Version)
+		}
+		ccPolicy, err := lifecyclePolicy(chaincodeParameters.Policy)
+		if err != nil {
+			return err
+		}
+		collections, err := chaincodeParameters.collectionConfigs()
+		if err != nil {
+			return err
+		}
+		req := resmgmt.LifecycleApproveCCRequest{
+			Name:             chaincodeParameters.ChaincodeID,
+			Version:          chaincodeParameters.Version,
+			PackageID:        chaincodeParameters.PackageID,
+			Sequence:         chaincodeParameters.Sequence,
+			SignaturePolicy:  ccPolicy,
+			CollectionConfig: collections,
+			InitRequired:     chaincodeParameters.InitRequired,
+		}
+		var txID fab.TransactionID
+		ordererEndpoint, err = c.withOrderer(ctx, func(ordererOptions []resmgmt.RequestOption) error {
+			var approveErr error
+			txID, approveErr = c.resMgmtClient.LifecycleApproveCC(channelID, req, append(ordererOptions, c.options.resMgmtOptions(ctx)...)...)
+			return approveErr
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to approve chaincode %s version %s sequence %d on channel %s.\n Error: %w", chaincodeParameters.ChaincodeID, chaincodeParameters.Version, chaincodeParameters.Sequence, channelID, classifyError(chaincodeParameters.ChaincodeID, string(txID), err))
+		}
+		if txID == "" {
+			return fmt.Errorf("Failed to approve chaincode %s version %s sequence %d on channel %s: transaction id is empty", chaincodeParameters.ChaincodeID, chaincodeParameters.Version, chaincodeParameters.Sequence, channelID)
+		}
+		logger.Debugf("Chaincode %s version %s sequence %d approved on channel %s", chaincodeParameters.ChaincodeID, chaincodeParameters.Version, chaincodeParameters.Sequence, channelID)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return ordererEndpoint, nil
+}
+
+// LifecycleCheckCommitReadiness returns approvals of chaincode definition by organizations. Key is MSP ID of organization
+func (c *ConfigurationClient) LifecycleCheckCommitReadiness(channelID string, chaincodeParameters *ChaincodeParameters) (map[string]bool, error) {
+	return c.LifecycleCheckCommitReadinessContext(context.Background(), channelID, chaincodeParameters)
+}
+
+// LifecycleCheckCommitReadinessContext is the same as LifecycleCheckCommitReadiness but request is bound to ctx
+func (c *ConfigurationClient) LifecycleCheckCommitReadinessContext(ctx context.Context, channelID string, chaincodeParameters *ChaincodeParameters) (map[string]bool, error) {
+	var approvals map[string]bool
+	err := c.fabricClient.do(ctx, func() error {
+		ccPolicy, err := lifecyclePolicy(chaincodeParameters.Policy)
+		if err != nil {
+			return err
+		}
+		collections, err := chaincodeParameters.collectionConfigs()
+		if err != nil {
+			return err
+		}
+		req := resmgmt.LifecycleCheckCCCommitReadinessRequest{
+			Name:             chaincodeParameters.ChaincodeID,
+			Version:          chaincodeParameters.Version,
+			Sequence:         chaincodeParameters.Sequence,
+			SignaturePolicy:  ccPolicy,
+			CollectionConfig: collections,
+			InitRequired:     chaincodeParameters.InitRequired,
+		}
+		resp, err := c.resMgmtClient.LifecycleCheckCCCommitReadiness(channelID, req, c.options.resMgmtOptions(ctx)...)
+		if err != nil {
+			return fmt.Errorf("Failed to check commit readiness of chaincode %s version %s sequence %d on channel %s.\n Error: %w", chaincodeParameters.ChaincodeID, chaincodeParameters.Version, chaincodeParameters.Sequence, channelID, classifyError(chaincodeParameters.ChaincodeID, "", err))
+		}
+		approvals = resp.Approvals
+		return nil
+	})
+	return approvals, err
+}
+
+// LifecycleCommitChaincode commits chaincode definition approved by enough organizations to channel
+func (c *ConfigurationClient) LifecycleCommitChaincode(channelID string, chaincodeParameters *ChaincodeParameters) error {
+	return c.LifecycleCommitChaincodeContext(context.Background(), channelID, chaincodeParameters)
+}
+
+// LifecycleCommitChaincodeContext is the same as LifecycleCommitChaincode but request is bound to ctx
+func (c *ConfigurationClient) LifecycleCommitChaincodeContext(ctx context.Context, channelID string, chaincodeParameters *ChaincodeParameters) error {
+	_, err := c.LifecycleCommitChaincodeWithOrderer(ctx, channelID, chaincodeParameters)
+	return err
+}
+
+// LifecycleCommitChaincodeWithOrderer is the same as LifecycleCommitChaincodeContext but also returns endpoint of orderer which served commit transaction.
+// Endpoint is empty if orderer is taken from channel config
+func (c *ConfigurationClient) LifecycleCommitChaincodeWithOrderer(ctx context.Context, channelID string, chaincodeParameters *ChaincodeParameters) (string, error) {
+	var ordererEndpoint string
+	err := c.fabricClient.do(ctx, func() error {
+		ccPolicy, err := lifecyclePolicy(chaincodeParameters.Policy)
+		if err != nil {
+			return err
+		}
+		collections, err := chaincodeParameters.collectionConfigs()
+		if err != nil {
+			return err
+		}
+		req := resmgmt.LifecycleCommitCCRequest{
+			Name:             chaincodeParameters.ChaincodeID,
+			Version:          chaincodeParameters.Version,
+			Sequence:         chaincodeParameters.Sequence,
+			SignaturePolicy:  ccPolicy,
+			CollectionConfig: collections,
+			InitRequired:     chaincodeParameters.InitRequired,
+		}
+		var txID fab.TransactionID
+		ordererEndpoint, err = c.withOrderer(ctx, func(ordererOptions []resmgmt.RequestOption) error {
+			var commitErr error
+			txID, commitErr = c.resMgmtClient.LifecycleCommitCC(channelID, req, append(ordererOptions, c.options.resMgmtOptions(ctx)...)...)
+			return commitErr
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to commit chaincode %s version %s sequence %d on channel %s.\n Error: %w", chaincodeParameters.ChaincodeID, chaincodeParameters.Version, chaincodeParameters.Sequence, channelID, classifyError(chaincodeParameters.ChaincodeID, string(txID), err))
+		}
+		if txID == "" {
+			return fmt.Errorf("Failed to commit chaincode %s version %s sequence %d on channel %s: transaction id is empty", chaincodeParameters.ChaincodeID, chaincodeParameters.Version, chaincodeParameters.Sequence, channelID)
+		}
+		logger.Debugf("Chaincode %s version %s sequence %d committed on channel %s", chaincodeParameters.ChaincodeID, chaincodeParameters.Version, chaincodeParameters.Sequence, channelID)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return ordererEndpoint, nil
+}
+
+// LifecycleQueryCommittedChaincode queries definitions of chaincode committed on channel
+func (c *ConfigurationClient) LifecycleQueryCommittedChaincode(channelID string, chaincodeID string) ([]CommittedChaincode, error) {
+	return c.LifecycleQueryCommittedChaincodeContext(context.Background(), channelID, chaincodeID)
+}
+
+// LifecycleQueryCommittedChaincodeContext is the same as LifecycleQueryCommittedChaincode but request is bound to ctx
+func (c *ConfigurationClient) LifecycleQueryCommittedChaincodeContext(ctx context.Context, channelID string, chaincodeID string) ([]CommittedChaincode, error) {
+	var committed []CommittedChaincode
+	err := c.fabricClient.do(ctx, func() error {
+		resp, err := c.resMgmtClient.LifecycleQueryCommittedCC(channelID, resmgmt.LifecycleQueryCommittedCCRequest{Name: chaincodeID},
+			c.options.resMgmtOptions(ctx)...)
+		if err != nil {
+			return fmt.Errorf("Failed to query committed chaincode %s on channel %s.\n Error: %w", chaincodeID, channelID, classifyError(chaincodeID, "", err))
+		}
+		committed = make([]CommittedChaincode, 0, len(resp))
+		for _, definition := range resp {
+			committed = append(committed, CommittedChaincode{
+				Name:                definition.Name,
+				Version:             definition.Version,
+				Sequence:            definition.Sequence,
+				InitRequired:        definition.InitRequired,
+				EndorsementPlugin:   definition.EndorsementPlugin,
+				ValidationPlugin:    definition.ValidationPlugin,
+				ChannelConfigPolicy: definition.ChannelConfigPolicy,
+				Approvals:           definition.Approvals,
+			})
+		}
+		return nil
+	})
+	return committed, err
+}
+
+// lifecyclePolicy returns nil for empty policy so channel default endorsement policy is used
+func lifecyclePolicy(policy string) (*common.SignaturePolicyEnvelope, error) {
+	if policy == "" {
+		return nil, nil
+	}
+	ccPolicy, err := policydsl.FromString(policy)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to construct signature policy from string %s.\n Error: %w", policy, err)
+	}
+	return ccPolicy, nil
+}
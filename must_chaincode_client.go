@@ -1,8 +1,10 @@
 package fabclient
 
+import "context"
+
 // MustCreateChaincodeClient is the same as CreateChaincodeClient but panics in case of error
-func MustCreateChaincodeClient(configPath string, ordererHost string, channelID string, chaincodeID string, name string, organization string) *ChaincodeClient {
-	result, err := CreateChaincodeClient(configPath, ordererHost, channelID, chaincodeID, name, organization)
+func MustCreateChaincodeClient(configPath string, ordererHost string, channelID string, chaincodeID string, name string, organization string, opts ...Option) *ChaincodeClient {
+	result, err := CreateChaincodeClient(configPath, ordererHost, channelID, chaincodeID, name, organization, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -10,8 +12,8 @@ func MustCreateChaincodeClient(configPath string, ordererHost string, channelID
 }
 
 // MustInvoke is the same as Invoke but panics in case of error
-func (c *ChaincodeClient) MustInvoke(functionName string, args [][]byte) []byte {
-	result, err := c.Invoke(functionName, args)
+func (c *ChaincodeClient) MustInvoke(functionName string, args [][]byte, opts ...Option) []byte {
+	result, err := c.Invoke(functionName, args, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -19,8 +21,107 @@ func (c *ChaincodeClient) MustInvoke(functionName string, args [][]byte) []byte
 }
 
 // MustQuery is the same as Query but panics in case of error
-func (c *ChaincodeClient) MustQuery(functionName string, args [][]byte) []byte {
-	result, err := c.Query(functionName, args)
+func (c *ChaincodeClient) MustQuery(functionName string, args [][]byte, opts ...Option) []byte {
+	result, err := c.Query(functionName, args, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustInvokeContext is the same as InvokeContext but panics in case of error
+func (c *ChaincodeClient) MustInvokeContext(ctx context.Context, functionName string, args [][]byte, opts ...Option) []byte {
+	result, err := c.InvokeContext(ctx, functionName, args, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustQueryContext is the same as QueryContext but panics in case of error
+func (c *ChaincodeClient) MustQueryContext(ctx context.Context, functionName string, args [][]byte, opts ...Option) []byte {
+	result, err := c.QueryContext(ctx, functionName, args, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustInvokeWithReceipt is the same as InvokeWithReceipt but panics in case of error
+func (c *ChaincodeClient) MustInvokeWithReceipt(functionName string, args [][]byte, opts ...Option) *Receipt {
+	result, err := c.InvokeWithReceipt(functionName, args, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustSubscribeEvents is the same as SubscribeEvents but panics in case of error
+func (c *ChaincodeClient) MustSubscribeEvents(filter string) (<-chan *ChaincodeEvent, Unsubscribe) {
+	events, unsubscribe, err := c.SubscribeEvents(filter)
+	if err != nil {
+		panic(err)
+	}
+	return events, unsubscribe
+}
+
+// MustInvokeAsync is the same as InvokeAsync but panics in case of error
+func (c *ChaincodeClient) MustInvokeAsync(functionName string, args [][]byte, opts ...Option) *InvokeHandle {
+	result, err := c.InvokeAsync(functionName, args, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustGetTransactionStatus is the same as GetTransactionStatus but panics in case of error
+func (c *ChaincodeClient) MustGetTransactionStatus(txID string) *TransactionStatus {
+	result, err := c.GetTransactionStatus(txID)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustWaitForTransaction is the same as WaitForTransaction but panics in case of error
+func (c *ChaincodeClient) MustWaitForTransaction(ctx context.Context, txID string) *TransactionStatus {
+	result, err := c.WaitForTransaction(ctx, txID)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustInvokeWithTransient is the same as InvokeWithTransient but panics in case of error
+func (c *ChaincodeClient) MustInvokeWithTransient(functionName string, args [][]byte, transient map[string][]byte, opts ...Option) []byte {
+	result, err := c.InvokeWithTransient(functionName, args, transient, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustQueryWithTransient is the same as QueryWithTransient but panics in case of error
+func (c *ChaincodeClient) MustQueryWithTransient(functionName string, args [][]byte, transient map[string][]byte, opts ...Option) []byte {
+	result, err := c.QueryWithTransient(functionName, args, transient, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustInvokeWithTransientContext is the same as InvokeWithTransientContext but panics in case of error
+func (c *ChaincodeClient) MustInvokeWithTransientContext(ctx context.Context, functionName string, args [][]byte, transient map[string][]byte, opts ...Option) []byte {
+	result, err := c.InvokeWithTransientContext(ctx, functionName, args, transient, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustQueryWithTransientContext is the same as QueryWithTransientContext but panics in case of error
+func (c *ChaincodeClient) MustQueryWithTransientContext(ctx context.Context, functionName string, args [][]byte, transient map[string][]byte, opts ...Option) []byte {
+	result, err := c.QueryWithTransientContext(ctx, functionName, args, transient, opts...)
 	if err != nil {
 		panic(err)
 	}
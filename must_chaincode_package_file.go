@@ -0,0 +1,28 @@
+package fabclient
+
+// MustPackageChaincode is the same as PackageChaincode but panics in case of error
+func MustPackageChaincode(chaincodeParameters *ChaincodeParameters, packagePath string) *ChaincodePackage {
+	result, err := PackageChaincode(chaincodeParameters, packagePath)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustReadChaincodePackage is the same as ReadChaincodePackage but panics in case of error
+func MustReadChaincodePackage(packagePath string) *ChaincodePackage {
+	result, err := ReadChaincodePackage(packagePath)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustListChaincodePackage is the same as ListChaincodePackage but panics in case of error
+func MustListChaincodePackage(packagePath string) *PackageContents {
+	result, err := ListChaincodePackage(packagePath)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
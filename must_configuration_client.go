@@ -1,8 +1,10 @@
 package fabclient
 
+import "context"
+
 // MustCreateConfigurationClient is the same as CreateConfigurationClient but panics in case of error
-func MustCreateConfigurationClient(configPath string, ordererHost string, name string, organization string) *ConfigurationClient {
-	result, err := CreateConfigurationClient(configPath, ordererHost, name, organization)
+func MustCreateConfigurationClient(configPath string, ordererHost string, name string, organization string, opts ...Option) *ConfigurationClient {
+	result, err := CreateConfigurationClient(configPath, ordererHost, name, organization, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -48,3 +50,213 @@ func (c *ConfigurationClient) MustCreateAndJoinChannel(channelID string, channel
 		panic(err)
 	}
 }
+
+// MustInstallChaincodeContext is the same as InstallChaincodeContext but panics in case of error
+func (c *ConfigurationClient) MustInstallChaincodeContext(ctx context.Context, chaincodeID string, chaincodePath string, version string) {
+	err := c.InstallChaincodeContext(ctx, chaincodeID, chaincodePath, version)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustInstanciateChaincodeContext is the same as InstanciateChaincodeContext but panics in case of error
+func (c *ConfigurationClient) MustInstanciateChaincodeContext(ctx context.Context, channelID string, chaincodeID string, chaincodePath string, version string, args [][]byte, policy string) {
+	err := c.InstanciateChaincodeContext(ctx, channelID, chaincodeID, chaincodePath, version, args, policy)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustCreateChannelContext is the same as CreateChannelContext but panics in case of error
+func (c *ConfigurationClient) MustCreateChannelContext(ctx context.Context, channelID string, channelConfigPath string) {
+	err := c.CreateChannelContext(ctx, channelID, channelConfigPath)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustJoinChannelContext is the same as JoinChannelContext but panics in case of error
+func (c *ConfigurationClient) MustJoinChannelContext(ctx context.Context, channelID string) {
+	err := c.JoinChannelContext(ctx, channelID)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustCreateAndJoinChannelContext is the same as CreateAndJoinChannelContext but panics in case of error
+func (c *ConfigurationClient) MustCreateAndJoinChannelContext(ctx context.Context, channelID string, channelConfigPath string) {
+	err := c.CreateAndJoinChannelContext(ctx, channelID, channelConfigPath)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustCreateChannelWithOrderer is the same as CreateChannelWithOrderer but panics in case of error
+func (c *ConfigurationClient) MustCreateChannelWithOrderer(ctx context.Context, channelID string, channelConfigPath string) string {
+	result, err := c.CreateChannelWithOrderer(ctx, channelID, channelConfigPath)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustJoinChannelWithOrderer is the same as JoinChannelWithOrderer but panics in case of error
+func (c *ConfigurationClient) MustJoinChannelWithOrderer(ctx context.Context, channelID string) string {
+	result, err := c.JoinChannelWithOrderer(ctx, channelID)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustUpgradeChaincode is the same as UpgradeChaincode but panics in case of error
+func (c *ConfigurationClient) MustUpgradeChaincode(channelID string, chaincodeID string, chaincodePath string, version string, args [][]byte, policy string) {
+	err := c.UpgradeChaincode(channelID, chaincodeID, chaincodePath, version, args, policy)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustUpgradeChaincodeFromStructure is the same as UpgradeChaincodeFromStructure but panics in case of error
+func (c *ConfigurationClient) MustUpgradeChaincodeFromStructure(channelID string, chaincodeParameters *ChaincodeParameters) {
+	err := c.UpgradeChaincodeFromStructure(channelID, chaincodeParameters)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustInstallChaincodeFromStructureContext is the same as InstallChaincodeFromStructureContext but panics in case of error
+func (c *ConfigurationClient) MustInstallChaincodeFromStructureContext(ctx context.Context, chaincodeParameters *ChaincodeParameters) {
+	err := c.InstallChaincodeFromStructureContext(ctx, chaincodeParameters)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustInstanciateChaincodeFromStructureContext is the same as InstanciateChaincodeFromStructureContext but panics in case of error
+func (c *ConfigurationClient) MustInstanciateChaincodeFromStructureContext(ctx context.Context, channelID string, chaincodeParameters *ChaincodeParameters) {
+	err := c.InstanciateChaincodeFromStructureContext(ctx, channelID, chaincodeParameters)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustCreateAndJoinChannelFromStructureContext is the same as CreateAndJoinChannelFromStructureContext but panics in case of error
+func (c *ConfigurationClient) MustCreateAndJoinChannelFromStructureContext(ctx context.Context, channelParameters *ChannelParameters) {
+	err := c.CreateAndJoinChannelFromStructureContext(ctx, channelParameters)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustDeployChaincode is the same as DeployChaincode but panics in case of error
+func (c *ConfigurationClient) MustDeployChaincode(channelID string, chaincodeParameters *ChaincodeParameters) {
+	err := c.DeployChaincode(channelID, chaincodeParameters)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustLifecyclePackageChaincode is the same as LifecyclePackageChaincode but panics in case of error
+func (c *ConfigurationClient) MustLifecyclePackageChaincode(chaincodeParameters *ChaincodeParameters) []byte {
+	result, err := c.LifecyclePackageChaincode(chaincodeParameters)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustLifecycleInstallChaincode is the same as LifecycleInstallChaincode but panics in case of error
+func (c *ConfigurationClient) MustLifecycleInstallChaincode(chaincodeParameters *ChaincodeParameters) string {
+	result, err := c.LifecycleInstallChaincode(chaincodeParameters)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustLifecycleQueryInstalledChaincodes is the same as LifecycleQueryInstalledChaincodes but panics in case of error
+func (c *ConfigurationClient) MustLifecycleQueryInstalledChaincodes() []InstalledChaincode {
+	result, err := c.LifecycleQueryInstalledChaincodes()
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustLifecycleApproveChaincode is the same as LifecycleApproveChaincode but panics in case of error
+func (c *ConfigurationClient) MustLifecycleApproveChaincode(channelID string, chaincodeParameters *ChaincodeParameters) {
+	err := c.LifecycleApproveChaincode(channelID, chaincodeParameters)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustLifecycleCheckCommitReadiness is the same as LifecycleCheckCommitReadiness but panics in case of error
+func (c *ConfigurationClient) MustLifecycleCheckCommitReadiness(channelID string, chaincodeParameters *ChaincodeParameters) map[string]bool {
+	result, err := c.LifecycleCheckCommitReadiness(channelID, chaincodeParameters)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustLifecycleCommitChaincode is the same as LifecycleCommitChaincode but panics in case of error
+func (c *ConfigurationClient) MustLifecycleCommitChaincode(channelID string, chaincodeParameters *ChaincodeParameters) {
+	err := c.LifecycleCommitChaincode(channelID, chaincodeParameters)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustLifecycleApproveChaincodeWithOrderer is the same as LifecycleApproveChaincodeWithOrderer but panics in case of error
+func (c *ConfigurationClient) MustLifecycleApproveChaincodeWithOrderer(ctx context.Context, channelID string, chaincodeParameters *ChaincodeParameters) string {
+	result, err := c.LifecycleApproveChaincodeWithOrderer(ctx, channelID, chaincodeParameters)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustLifecycleCommitChaincodeWithOrderer is the same as LifecycleCommitChaincodeWithOrderer but panics in case of error
+func (c *ConfigurationClient) MustLifecycleCommitChaincodeWithOrderer(ctx context.Context, channelID string, chaincodeParameters *ChaincodeParameters) string {
+	result, err := c.LifecycleCommitChaincodeWithOrderer(ctx, channelID, chaincodeParameters)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustLifecycleQueryCommittedChaincode is the same as LifecycleQueryCommittedChaincode but panics in case of error
+func (c *ConfigurationClient) MustLifecycleQueryCommittedChaincode(channelID string, chaincodeID string) []CommittedChaincode {
+	result, err := c.LifecycleQueryCommittedChaincode(channelID, chaincodeID)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustInstallChaincodePackage is the same as InstallChaincodePackage but panics in case of error
+func (c *ConfigurationClient) MustInstallChaincodePackage(packagePath string) {
+	err := c.InstallChaincodePackage(packagePath)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustInstallExternalChaincode is the same as InstallExternalChaincode but panics in case of error
+func (c *ConfigurationClient) MustInstallExternalChaincode(externalParameters *ExternalChaincodeParameters) string {
+	result, err := c.InstallExternalChaincode(externalParameters)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustInstallExternalChaincodeLegacy is the same as InstallExternalChaincodeLegacy but panics in case of error
+func (c *ConfigurationClient) MustInstallExternalChaincodeLegacy(chaincodeID string, version string, externalParameters *ExternalChaincodeParameters) {
+	err := c.InstallExternalChaincodeLegacy(chaincodeID, version, externalParameters)
+	if err != nil {
+		panic(err)
+	}
+}
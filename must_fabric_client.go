@@ -10,8 +10,8 @@ func MustCreateFabricClient(configPath string, ordererHost string) *FabricClient
 }
 
 // MustCreateConfigurationClient is the same as CreateConfigurationClient but panics in case of error
-func (c *FabricClient) MustCreateConfigurationClient(name string, organization string) *ConfigurationClient {
-	result, err := c.CreateConfigurationClient(name, organization)
+func (c *FabricClient) MustCreateConfigurationClient(name string, organization string, opts ...Option) *ConfigurationClient {
+	result, err := c.CreateConfigurationClient(name, organization, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -19,8 +19,8 @@ func (c *FabricClient) MustCreateConfigurationClient(name string, organization s
 }
 
 // MustCreateUserClient is the same as CreateUserClient but panics in case of error
-func (c *FabricClient) MustCreateUserClient(channelID string, name string, organization string) *UserClient {
-	result, err := c.CreateUserClient(channelID, name, organization)
+func (c *FabricClient) MustCreateUserClient(channelID string, name string, organization string, opts ...Option) *UserClient {
+	result, err := c.CreateUserClient(channelID, name, organization, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -28,8 +28,53 @@ func (c *FabricClient) MustCreateUserClient(channelID string, name string, organ
 }
 
 // MustCreateChaincodeClient is the same as CreateChaincodeClient but panics in case of error
-func (c *FabricClient) MustCreateChaincodeClient(channelID string, chaincodeID string, name string, organization string) *ChaincodeClient {
-	result, err := c.CreateChaincodeClient(channelID, chaincodeID, name, organization)
+func (c *FabricClient) MustCreateChaincodeClient(channelID string, chaincodeID string, name string, organization string, opts ...Option) *ChaincodeClient {
+	result, err := c.CreateChaincodeClient(channelID, chaincodeID, name, organization, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustCreateBlockListener is the same as CreateBlockListener but panics in case of error
+func (c *FabricClient) MustCreateBlockListener(channelID string, name string, organization string, mode BlockListenerMode, start StartPosition, checkpointer Checkpointer) *BlockListener {
+	result, err := c.CreateBlockListener(channelID, name, organization, mode, start, checkpointer)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustCreateLedgerClient is the same as CreateLedgerClient but panics in case of error
+func (c *FabricClient) MustCreateLedgerClient(channelID string, name string, organization string) *LedgerClient {
+	result, err := c.CreateLedgerClient(channelID, name, organization)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustCreateFabricClientFromConfig is the same as CreateFabricClientFromConfig but panics in case of error
+func MustCreateFabricClientFromConfig(cfg *Config, ordererHost string) *FabricClient {
+	result, err := CreateFabricClientFromConfig(cfg, ordererHost)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustCreateFabricClientFromRaw is the same as CreateFabricClientFromRaw but panics in case of error
+func MustCreateFabricClientFromRaw(raw []byte, configType string, ordererHost string) *FabricClient {
+	result, err := CreateFabricClientFromRaw(raw, configType, ordererHost)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustCreateFabricClientWithOrderers is the same as CreateFabricClientWithOrderers but panics in case of error
+func MustCreateFabricClientWithOrderers(configPath string, ordererHosts []string, selection OrdererSelection) *FabricClient {
+	result, err := CreateFabricClientWithOrderers(configPath, ordererHosts, selection)
 	if err != nil {
 		panic(err)
 	}
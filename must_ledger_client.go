@@ -0,0 +1,70 @@
+package fabclient
+
+import (
+	"github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+)
+
+// MustCreateLedgerClient is the same as CreateLedgerClient but panics in case of error
+func MustCreateLedgerClient(configPath string, ordererHost string, channelID string, name string, organization string) *LedgerClient {
+	result, err := CreateLedgerClient(configPath, ordererHost, channelID, name, organization)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustQueryInfo is the same as QueryInfo but panics in case of error
+func (c *LedgerClient) MustQueryInfo() *ChainInfo {
+	result, err := c.QueryInfo()
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustQueryBlock is the same as QueryBlock but panics in case of error
+func (c *LedgerClient) MustQueryBlock(blockNumber uint64) *common.Block {
+	result, err := c.QueryBlock(blockNumber)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustQueryBlockByHash is the same as QueryBlockByHash but panics in case of error
+func (c *LedgerClient) MustQueryBlockByHash(blockHash []byte) *common.Block {
+	result, err := c.QueryBlockByHash(blockHash)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustQueryBlockByTxID is the same as QueryBlockByTxID but panics in case of error
+func (c *LedgerClient) MustQueryBlockByTxID(txID string) *common.Block {
+	result, err := c.QueryBlockByTxID(txID)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustQueryTransaction is the same as QueryTransaction but panics in case of error
+func (c *LedgerClient) MustQueryTransaction(txID string) *pb.ProcessedTransaction {
+	result, err := c.QueryTransaction(txID)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustQueryConfig is the same as QueryConfig but panics in case of error
+func (c *LedgerClient) MustQueryConfig() fab.ChannelCfg {
+	result, err := c.QueryConfig()
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
@@ -1,8 +1,13 @@
 package fabclient
 
+import (
+	"context"
+	"time"
+)
+
 // MustCreateUserClient is the same as CreateUserClient but panics in case of error
-func MustCreateUserClient(configPath string, ordererHost string, channelID string, name string, organization string) *UserClient {
-	result, err := CreateUserClient(configPath, ordererHost, channelID, name, organization)
+func MustCreateUserClient(configPath string, ordererHost string, channelID string, name string, organization string, opts ...Option) *UserClient {
+	result, err := CreateUserClient(configPath, ordererHost, channelID, name, organization, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -10,8 +15,8 @@ func MustCreateUserClient(configPath string, ordererHost string, channelID strin
 }
 
 // MustInvoke is the same as Invoke but panics in case of error
-func (c *UserClient) MustInvoke(chaincodeID string, functionName string, args [][]byte) []byte {
-	result, err := c.Invoke(chaincodeID, functionName, args)
+func (c *UserClient) MustInvoke(chaincodeID string, functionName string, args [][]byte, opts ...Option) []byte {
+	result, err := c.Invoke(chaincodeID, functionName, args, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -19,8 +24,116 @@ func (c *UserClient) MustInvoke(chaincodeID string, functionName string, args []
 }
 
 // MustQuery is the same as Query but panics in case of error
-func (c *UserClient) MustQuery(chaincodeID string, functionName string, args [][]byte) []byte {
-	result, err := c.Query(chaincodeID, functionName, args)
+func (c *UserClient) MustQuery(chaincodeID string, functionName string, args [][]byte, opts ...Option) []byte {
+	result, err := c.Query(chaincodeID, functionName, args, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustInvokeContext is the same as InvokeContext but panics in case of error
+func (c *UserClient) MustInvokeContext(ctx context.Context, chaincodeID string, functionName string, args [][]byte, opts ...Option) []byte {
+	result, err := c.InvokeContext(ctx, chaincodeID, functionName, args, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustQueryContext is the same as QueryContext but panics in case of error
+func (c *UserClient) MustQueryContext(ctx context.Context, chaincodeID string, functionName string, args [][]byte, opts ...Option) []byte {
+	result, err := c.QueryContext(ctx, chaincodeID, functionName, args, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustInvokeWithReceipt is the same as InvokeWithReceipt but panics in case of error
+func (c *UserClient) MustInvokeWithReceipt(chaincodeID string, functionName string, args [][]byte, opts ...Option) *Receipt {
+	result, err := c.InvokeWithReceipt(chaincodeID, functionName, args, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustSubscribeEvents is the same as SubscribeEvents but panics in case of error
+func (c *UserClient) MustSubscribeEvents(chaincodeID string, filter string) (<-chan *ChaincodeEvent, Unsubscribe) {
+	events, unsubscribe, err := c.SubscribeEvents(chaincodeID, filter)
+	if err != nil {
+		panic(err)
+	}
+	return events, unsubscribe
+}
+
+// MustInvokeAsync is the same as InvokeAsync but panics in case of error
+func (c *UserClient) MustInvokeAsync(chaincodeID string, functionName string, args [][]byte, opts ...Option) *InvokeHandle {
+	result, err := c.InvokeAsync(chaincodeID, functionName, args, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustCreateOutbox is the same as CreateOutbox but panics in case of error
+func (c *UserClient) MustCreateOutbox(path string, retryInterval time.Duration) *Outbox {
+	result, err := c.CreateOutbox(path, retryInterval)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustGetTransactionStatus is the same as GetTransactionStatus but panics in case of error
+func (c *UserClient) MustGetTransactionStatus(txID string) *TransactionStatus {
+	result, err := c.GetTransactionStatus(txID)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustWaitForTransaction is the same as WaitForTransaction but panics in case of error
+func (c *UserClient) MustWaitForTransaction(ctx context.Context, txID string) *TransactionStatus {
+	result, err := c.WaitForTransaction(ctx, txID)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustInvokeWithTransient is the same as InvokeWithTransient but panics in case of error
+func (c *UserClient) MustInvokeWithTransient(chaincodeID string, functionName string, args [][]byte, transient map[string][]byte, opts ...Option) []byte {
+	result, err := c.InvokeWithTransient(chaincodeID, functionName, args, transient, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustQueryWithTransient is the same as QueryWithTransient but panics in case of error
+func (c *UserClient) MustQueryWithTransient(chaincodeID string, functionName string, args [][]byte, transient map[string][]byte, opts ...Option) []byte {
+	result, err := c.QueryWithTransient(chaincodeID, functionName, args, transient, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustInvokeWithTransientContext is the same as InvokeWithTransientContext but panics in case of error
+func (c *UserClient) MustInvokeWithTransientContext(ctx context.Context, chaincodeID string, functionName string, args [][]byte, transient map[string][]byte, opts ...Option) []byte {
+	result, err := c.InvokeWithTransientContext(ctx, chaincodeID, functionName, args, transient, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustQueryWithTransientContext is the same as QueryWithTransientContext but panics in case of error
+func (c *UserClient) MustQueryWithTransientContext(ctx context.Context, chaincodeID string, functionName string, args [][]byte, transient map[string][]byte, opts ...Option) []byte {
+	result, err := c.QueryWithTransientContext(ctx, chaincodeID, functionName, args, transient, opts...)
 	if err != nil {
 		panic(err)
 	}
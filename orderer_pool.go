@@ -0,0 +1,101 @@
+package fabclient
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"google.golang.org/grpc/codes"
+)
+
+// OrdererSelection defines order in which orderer endpoints are tried
+type OrdererSelection int
+
+const (
+	// OrderedFailover always starts from first orderer and tries next one if request fails
+	OrderedFailover OrdererSelection = iota
+	// RoundRobin starts every request from orderer following the one used by previous request
+	RoundRobin
+	// RandomSelection starts every request from random orderer
+	RandomSelection
+)
+
+// ordererPool holds orderer endpoints of fabric client
+type ordererPool struct {
+	endpoints []string
+	selection OrdererSelection
+	lock      sync.Mutex
+	next      int
+	random    *rand.Rand
+}
+
+func newOrdererPool(endpoints []string, selection OrdererSelection) *ordererPool {
+	return &ordererPool{
+		endpoints: append([]string(nil), endpoints...),
+		selection: selection,
+		random:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// sequence returns all endpoints in order they are tried for one request
+func (p *ordererPool) sequence() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.endpoints) == 0 {
+		return nil
+	}
+	start := 0
+	switch p.selection {
+	case RoundRobin:
+		start = p.next
+		p.next = (p.next + 1) % len(p.endpoints)
+	case RandomSelection:
+		start = p.random.Intn(len(p.endpoints))
+	}
+	return append(append([]string(nil), p.endpoints[start:]...), p.endpoints[:start]...)
+}
+
+// withOrderer calls request with orderer endpoints of fabric client until one succeeds and returns endpoint which served request.
+// If fabric client has no orderer endpoints, request is called without orderer option so orderer is taken from channel config
+func (c *ConfigurationClient) withOrderer(ctx context.Context, request func(ordererOptions []resmgmt.RequestOption) error) (string, error) {
+	endpoints := c.fabricClient.orderers.sequence()
+	if len(endpoints) == 0 {
+		return "", request(nil)
+	}
+	var err error
+	for _, endpoint := range endpoints {
+		err = request([]resmgmt.RequestOption{resmgmt.WithOrdererEndpoint(endpoint)})
+		if err == nil {
+			logger.Debugf("Request served by orderer %s", endpoint)
+			return endpoint, nil
+		}
+		if ctx.Err() != nil || !isOrdererUnavailable(err) {
+			return "", err
+		}
+		logger.Warnf("Orderer %s is unavailable, trying next orderer.\n Error: %v", endpoint, err)
+	}
+	return "", err
+}
+
+// isOrdererUnavailable reports whether request failed before transaction was accepted by orderer, so it is safe to send it to other orderer.
+// Other errors are returned as is because transaction could be already ordered
+func isOrdererUnavailable(err error) bool {
+	s, ok := sdkStatus(err)
+	if !ok {
+		return false
+	}
+	switch s.Group {
+	case status.OrdererClientStatus:
+		return s.Code == status.ConnectionFailed.ToInt32()
+	case status.GRPCTransportStatus:
+		return s.Code == int32(codes.Unavailable)
+	case status.OrdererServerStatus:
+		return s.Code == int32(common.Status_SERVICE_UNAVAILABLE)
+	default:
+		return false
+	}
+}
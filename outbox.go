@@ -0,0 +1,549 @@
+package fabclient
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+)
+
+const (
+	outboxNonceSize            = 24
+	defaultOutboxRetryInterval = time.Second
+	outboxCompactionThreshold  = 1000
+)
+
+// OutboxResult is outcome of delivery of transaction stored in Outbox. Err is set if transaction can not be committed
+type OutboxResult struct {
+	// ID is id of transaction returned by Outbox.Add
+	ID      string
+	Receipt *Receipt
+	Err     error
+}
+
+// outboxRecord is line of outbox log
+type outboxRecord struct {
+	Op           string   `json:"op"`
+	ID           string   `json:"id"`
+	TxID         string   `json:"txId,omitempty"`
+	Nonce        []byte   `json:"nonce,omitempty"`
+	ChaincodeID  string   `json:"chaincodeId,omitempty"`
+	FunctionName string   `json:"functionName,omitempty"`
+	Args         [][]byte `json:"args,omitempty"`
+}
+
+const (
+	outboxAdd      = "add"
+	outboxResubmit = "resubmit"
+	outboxDone     = "done"
+)
+
+// outboxEntry is pending transaction. checked is false until it is known that current transaction id is not committed
+type outboxEntry struct {
+	record   outboxRecord
+	attempts int
+	checked  bool
+}
+
+// Outbox stores transactions in file log before they are sent and delivers them in background.
+// Transaction id is generated by client and stored with transaction, so after restart Outbox checks ledger
+// and sends only transactions which are not committed yet. Every transaction takes effect on channel at most once
+type Outbox struct {
+	path          string
+	userClient    *UserClient
+	ledgerClient  *LedgerClient
+	creator       []byte
+	retryInterval time.Duration
+	lock          sync.Mutex
+	file          *os.File
+	entries       []*outboxEntry
+	wake          chan struct{}
+	done          chan struct{}
+	cancel        context.CancelFunc
+	stopped       sync.WaitGroup
+	stopOnce      sync.Once
+	// records is number of records in log. Log is compacted when it mostly contains records of delivered transactions
+	records int
+}
+
+// CreateOutbox opens outbox log with passed path or creates it. Transactions which are not delivered before restart are loaded from log.
+// Transactions which fail because of connection problems are retried every retryInterval, zero means one second
+func (c *UserClient) CreateOutbox(path string, retryInterval time.Duration) (*Outbox, error) {
+	if err := c.fabricClient.checkOpen(); err != nil {
+		return nil, err
+	}
+	if retryInterval <= 0 {
+		retryInterval = defaultOutboxRetryInterval
+	}
+	creator, err := c.signingIdentity.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to serialize identity of user %s.\n Error: %w", c.name, err)
+	}
+	records, err := readOutboxLog(path)
+	if err != nil {
+		return nil, err
+	}
+	outbox := &Outbox{
+		path:          path,
+		userClient:    c,
+		ledgerClient:  c.ledgerClient,
+		creator:       creator,
+		retryInterval: retryInterval,
+		entries:       pendingOutboxEntries(records),
+		wake:          make(chan struct{}, 1),
+	}
+	if err = outbox.compact(); err != nil {
+		return nil, err
+	}
+	logger.Debugf("Outbox %s created with %d pending transactions", path, len(outbox.entries))
+	return outbox, nil
+}
+
+// Add stores transaction in outbox log and returns its id. Transaction is delivered by started outbox.
+// Returned id is id of transaction on channel unless transaction is resubmitted after read conflict
+func (o *Outbox) Add(chaincodeID string, functionName string, args [][]byte) (string, error) {
+	nonce, txID, err := o.newTxID()
+	if err != nil {
+		return "", err
+	}
+	record := outboxRecord{Op: outboxAdd, ID: txID, TxID: txID, Nonce: nonce, ChaincodeID: chaincodeID, FunctionName: functionName, Args: args}
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if o.file == nil {
+		return "", fmt.Errorf("Failed to add transaction to outbox %s: outbox is stopped", o.path)
+	}
+	if err = o.append(record); err != nil {
+		return "", err
+	}
+	// entry added to log is checked because transaction id is new
+	o.entries = append(o.entries, &outboxEntry{record: record, checked: true})
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return txID, nil
+}
+
+// Pending returns ids of transactions which are not delivered yet
+func (o *Outbox) Pending() []string {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	ids := make([]string, 0, len(o.entries))
+	for _, entry := range o.entries {
+		ids = append(ids, entry.record.ID)
+	}
+	return ids
+}
+
+// Start starts delivery of transactions in background and returns channel of results. Results must be read, otherwise delivery is suspended.
+// Channel is closed when outbox is stopped. Started outbox is stopped when Fabric Client is closed
+func (o *Outbox) Start() (<-chan *OutboxResult, error) {
+	if err := o.userClient.fabricClient.checkOpen(); err != nil {
+		return nil, err
+	}
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if o.file == nil || o.done != nil {
+		return nil, fmt.Errorf("Failed to start outbox %s: outbox is already started or stopped", o.path)
+	}
+	err := o.userClient.fabricClient.track(o, func() {
+		if err := o.Stop(); err != nil {
+			logger.Warnf("%v", err)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	o.cancel = cancel
+	o.done = make(chan struct{})
+	results := make(chan *OutboxResult)
+	o.stopped.Add(1)
+	go o.submit(ctx, results)
+	logger.Debugf("Outbox %s started", o.path)
+	return results, nil
+}
+
+// Stop stops delivery, closes channel of results, compacts and closes outbox log. Transaction which is being delivered is checked on ledger after restart
+func (o *Outbox) Stop() error {
+	var err error
+	o.stopOnce.Do(func() {
+		o.lock.Lock()
+		if o.done != nil {
+			close(o.done)
+			o.cancel()
+		}
+		o.lock.Unlock()
+		o.userClient.fabricClient.untrack(o)
+		o.stopped.Wait()
+		o.lock.Lock()
+		defer o.lock.Unlock()
+		err = o.compact()
+		if o.file != nil {
+			if closeErr := o.file.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("Failed to close outbox log %s.\n Error: %w", o.path, closeErr)
+			}
+			o.file = nil
+		}
+		logger.Debugf("Outbox %s stopped", o.path)
+	})
+	return err
+}
+
+// submit delivers pending transactions one by one in order of adding. Transaction which should be tried again later is moved to the back of queue
+// so it does not block other transactions
+func (o *Outbox) submit(ctx context.Context, results chan<- *OutboxResult) {
+	defer o.stopped.Done()
+	defer close(results)
+	for {
+		entry := o.next()
+		if entry == nil {
+			select {
+			case <-o.wake:
+				continue
+			case <-o.done:
+				return
+			}
+		}
+		result, delivered := o.deliver(ctx, entry)
+		if !delivered {
+			o.postpone(entry)
+			logger.Debugf("Delivery of transaction %s from outbox %s is postponed.\n Error: %v", entry.record.ID, o.path, result.Err)
+			select {
+			case <-time.After(o.retryInterval):
+				continue
+			case <-o.done:
+				return
+			}
+		}
+		if err := o.complete(entry); err != nil {
+			logger.Errorf("%v", err)
+			return
+		}
+		select {
+		case results <- result:
+		case <-o.done:
+			return
+		}
+	}
+}
+
+// deliver checks ledger if it is not known whether transaction is committed and sends transaction if it is not committed.
+// delivered is false if transaction should be tried again later
+func (o *Outbox) deliver(ctx context.Context, entry *outboxEntry) (result *OutboxResult, delivered bool) {
+	result = &OutboxResult{ID: entry.record.ID}
+	if !entry.checked {
+		transaction, err := o.ledgerClient.QueryTransactionContext(ctx, entry.record.TxID)
+		switch {
+		case errors.Is(err, ErrTransactionNotFound):
+			entry.checked = true
+		case err != nil:
+			result.Err = err
+			return result, false
+		case pb.TxValidationCode(transaction.ValidationCode) == pb.TxValidationCode_VALID:
+			result.Receipt = o.committedReceipt(ctx, entry)
+			return result, true
+		default:
+			// invalid transaction does not take effect so it is resubmitted with new id
+			if err = o.resubmit(entry); err != nil {
+				result.Err = err
+				return result, false
+			}
+		}
+	}
+	entry.attempts++
+	resp, err := o.userClient.executeWithNonce(ctx, entry.record.ChaincodeID, entry.record.FunctionName, entry.record.Args, entry.record.Nonce, o.creator)
+	if err == nil {
+		receipt, err := newReceipt(resp)
+		if err != nil {
+			receipt = &Receipt{TxID: string(resp.TransactionID), ValidationCode: resp.TxValidationCode, BlockNumber: resp.BlockNumber}
+		}
+		receipt.Attempts = entry.attempts
+		result.Receipt = receipt
+		return result, true
+	}
+	result.Err = err
+	switch failedDeliveryAction(err) {
+	case outboxResubmitWithNewID:
+		if err = o.resubmit(entry); err != nil {
+			result.Err = err
+		}
+		return result, false
+	case outboxReportFailure:
+		return result, true
+	default:
+		entry.checked = false
+		return result, false
+	}
+}
+
+// outboxAction is what outbox does with entry after failed attempt to deliver it
+type outboxAction int
+
+const (
+	// outboxCheckLedger means that transaction could reach orderer, so it is checked on ledger before next attempt
+	outboxCheckLedger outboxAction = iota
+	// outboxResubmitWithNewID means that transaction is invalidated with read conflict and is sent again with new id
+	outboxResubmitWithNewID
+	// outboxReportFailure means that sending transaction again would not help, so failure is reported
+	outboxReportFailure
+)
+
+// failedDeliveryAction returns action for transaction which failed with err
+func failedDeliveryAction(err error) outboxAction {
+	var txErr *TransactionError
+	var endorsementErr *EndorsementError
+	var identityErr *IdentityError
+	switch {
+	case errors.As(err, &txErr) && txErr.ValidationCode == pb.TxValidationCode_DUPLICATE_TXID,
+		isDuplicateTransaction(err):
+		// transaction with the same id was sent before, its validation code is checked on ledger
+		return outboxCheckLedger
+	case errors.As(err, &txErr) && txErr.Retryable():
+		return outboxResubmitWithNewID
+	case errors.As(err, &txErr), errors.Is(err, ErrChaincode):
+		return outboxReportFailure
+	case errors.As(err, &endorsementErr) && !endorsementErr.Retryable(), errors.As(err, &identityErr):
+		// transaction is rejected before it is sent to orderer
+		return outboxReportFailure
+	default:
+		return outboxCheckLedger
+	}
+}
+
+// committedReceipt returns receipt of transaction found on ledger. Block number is zero if block can not be queried
+func (o *Outbox) committedReceipt(ctx context.Context, entry *outboxEntry) *Receipt {
+	receipt := &Receipt{TxID: entry.record.TxID, ValidationCode: pb.TxValidationCode_VALID, Attempts: entry.attempts}
+	if block, err := o.ledgerClient.QueryBlockByTxIDContext(ctx, entry.record.TxID); err == nil {
+		receipt.BlockNumber = block.Header.Number
+	}
+	return receipt
+}
+
+// resubmit assigns new transaction id to entry and stores it in log before transaction is sent
+func (o *Outbox) resubmit(entry *outboxEntry) error {
+	nonce, txID, err := o.newTxID()
+	if err != nil {
+		return err
+	}
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if err = o.append(outboxRecord{Op: outboxResubmit, ID: entry.record.ID, TxID: txID, Nonce: nonce}); err != nil {
+		return err
+	}
+	entry.record.TxID = txID
+	entry.record.Nonce = nonce
+	entry.checked = true
+	return nil
+}
+
+// complete removes delivered entry from outbox
+func (o *Outbox) complete(entry *outboxEntry) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if err := o.append(outboxRecord{Op: outboxDone, ID: entry.record.ID}); err != nil {
+		return err
+	}
+	for i, pending := range o.entries {
+		if pending == entry {
+			o.entries = append(o.entries[:i], o.entries[i+1:]...)
+			break
+		}
+	}
+	if o.records > outboxCompactionThreshold && o.records > 2*len(o.entries) {
+		if err := o.compact(); err != nil {
+			logger.Warnf("%v", err)
+		}
+	}
+	return nil
+}
+
+// postpone moves entry to the back of queue
+func (o *Outbox) postpone(entry *outboxEntry) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for i, pending := range o.entries {
+		if pending == entry {
+			o.entries = append(append(o.entries[:i], o.entries[i+1:]...), entry)
+			return
+		}
+	}
+}
+
+func (o *Outbox) next() *outboxEntry {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if len(o.entries) == 0 {
+		return nil
+	}
+	return o.entries[0]
+}
+
+// newTxID generates nonce and computes id of transaction created by user with it the same way as Fabric does
+func (o *Outbox) newTxID() ([]byte, string, error) {
+	nonce := make([]byte, outboxNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, "", fmt.Errorf("Failed to generate nonce of transaction.\n Error: %w", err)
+	}
+	hash := sha256.Sum256(append(append([]byte(nil), nonce...), o.creator...))
+	return nonce, hex.EncodeToString(hash[:]), nil
+}
+
+// append writes record to log and syncs it to disk. Lock must be held
+func (o *Outbox) append(record outboxRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal outbox record of transaction %s.\n Error: %w", record.ID, err)
+	}
+	if _, err = o.file.Write(append(line, '\n')); err == nil {
+		err = o.file.Sync()
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to write outbox log %s.\n Error: %w", o.path, err)
+	}
+	o.records++
+	return nil
+}
+
+// compact replaces log with pending entries atomically and opens it for appending. Lock must be held
+func (o *Outbox) compact() error {
+	tmpFile, err := ioutil.TempFile(filepath.Dir(o.path), filepath.Base(o.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("Failed to create temporary outbox log for %s.\n Error: %w", o.path, err)
+	}
+	defer os.Remove(tmpFile.Name())
+	file := o.file
+	o.file = tmpFile
+	o.records = 0
+	for _, entry := range o.entries {
+		record := entry.record
+		record.Op = outboxAdd
+		if err = o.append(record); err != nil {
+			break
+		}
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	o.file = file
+	if err != nil {
+		return fmt.Errorf("Failed to write outbox log %s.\n Error: %w", o.path, err)
+	}
+	if err = os.Rename(tmpFile.Name(), o.path); err != nil {
+		return fmt.Errorf("Failed to replace outbox log %s.\n Error: %w", o.path, err)
+	}
+	if file != nil {
+		// records appended to replaced log would be lost, so outbox is unusable until it is reopened
+		file.Close()
+		o.file = nil
+	}
+	o.file, err = os.OpenFile(o.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to open outbox log %s.\n Error: %w", o.path, err)
+	}
+	return nil
+}
+
+// readOutboxLog reads records of log. Incomplete last line which is left by crash during writing is ignored
+func readOutboxLog(path string) ([]outboxRecord, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open outbox log %s.\n Error: %w", path, err)
+	}
+	defer file.Close()
+	var records []outboxRecord
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(line) > 0 {
+				logger.Warnf("Incomplete record at the end of outbox log %s is ignored", path)
+			}
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read outbox log %s.\n Error: %w", path, err)
+		}
+		var record outboxRecord
+		if err = json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("Failed to parse record of outbox log %s.\n Error: %w", path, err)
+		}
+		records = append(records, record)
+	}
+}
+
+// pendingOutboxEntries replays log records. Loaded entries are not checked because they could be sent before restart
+func pendingOutboxEntries(records []outboxRecord) []*outboxEntry {
+	var entries []*outboxEntry
+	byID := make(map[string]*outboxEntry)
+	for _, record := range records {
+		switch record.Op {
+		case outboxAdd:
+			entry := &outboxEntry{record: record}
+			byID[record.ID] = entry
+			entries = append(entries, entry)
+		case outboxResubmit:
+			if entry, ok := byID[record.ID]; ok {
+				entry.record.TxID = record.TxID
+				entry.record.Nonce = record.Nonce
+			}
+		case outboxDone:
+			delete(byID, record.ID)
+		}
+	}
+	pending := entries[:0]
+	for _, entry := range entries {
+		if byID[entry.record.ID] == entry {
+			pending = append(pending, entry)
+		}
+	}
+	return pending
+}
+
+// executeWithNonce sends transaction with id defined by nonce and creator and waits for its commit. Transaction is not resubmitted
+func (c *UserClient) executeWithNonce(ctx context.Context, chaincodeID string, functionName string, args [][]byte, nonce []byte, creator []byte) (committedResponse, error) {
+	headerOptions := func() []fab.TxnHeaderOpt {
+		return []fab.TxnHeaderOpt{fab.WithNonce(nonce), fab.WithCreator(creator)}
+	}
+	commit := &commitHandler{}
+	handler := invoke.NewProposalProcessorHandler(
+		invoke.NewEndorsementHandlerWithOpts(
+			invoke.NewEndorsementValidationHandler(
+				invoke.NewSignatureValidationHandler(commit),
+			),
+			headerOptions,
+		),
+	)
+	var resp channel.Response
+	err := c.fabricClient.do(ctx, func() (err error) {
+		// fabric-sdk-go retries would send the same transaction id again, so outbox decides itself how to send transaction again
+		options := c.options.with([]Option{WithRetryAttempts(0)})
+		resp, err = c.channelClient.InvokeHandler(handler, channel.Request{ChaincodeID: chaincodeID, Fcn: functionName, Args: args}, options.executeOptions(ctx)...)
+		if err != nil {
+			return fmt.Errorf("Failed to invoke chaincode %s with function %s and arguments %v.\n Error: %w", chaincodeID, functionName, args, classifyError(chaincodeID, string(resp.TransactionID), err))
+		}
+		return nil
+	})
+	if err != nil {
+		return committedResponse{}, err
+	}
+	return committedResponse{Response: resp, BlockNumber: commit.blockNumber}, nil
+}
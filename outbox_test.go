@@ -0,0 +1,235 @@
+package fabclient
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+)
+
+func writeOutboxLog(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "outbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "outbox.log")
+	if content != "" {
+		if err = ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+func TestReadOutboxLog(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		ids     []string
+		fails   bool
+	}{
+		{name: "missing log", content: "", ids: nil},
+		{
+			name:    "complete records",
+			content: "{\"op\":\"add\",\"id\":\"a\"}\n{\"op\":\"done\",\"id\":\"a\"}\n",
+			ids:     []string{"a", "a"},
+		},
+		{
+			name:    "torn last line is ignored",
+			content: "{\"op\":\"add\",\"id\":\"a\"}\n{\"op\":\"add\",\"id\":\"b\"",
+			ids:     []string{"a"},
+		},
+		{
+			name:    "corrupted record in the middle fails",
+			content: "{\"op\":\"add\",\"id\":\"a\"}\nnot json\n{\"op\":\"add\",\"id\":\"b\"}\n",
+			fails:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			records, err := readOutboxLog(writeOutboxLog(t, test.content))
+			if test.fails {
+				if err == nil {
+					t.Fatal("error is expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, record := range records {
+				ids = append(ids, record.ID)
+			}
+			if !reflect.DeepEqual(ids, test.ids) {
+				t.Fatalf("read records %v, expected %v", ids, test.ids)
+			}
+		})
+	}
+}
+
+func TestPendingOutboxEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []outboxRecord
+		pending []string
+		txIDs   []string
+	}{
+		{
+			name:    "entries keep order of adding",
+			records: []outboxRecord{{Op: outboxAdd, ID: "a", TxID: "a"}, {Op: outboxAdd, ID: "b", TxID: "b"}, {Op: outboxAdd, ID: "c", TxID: "c"}},
+			pending: []string{"a", "b", "c"},
+			txIDs:   []string{"a", "b", "c"},
+		},
+		{
+			name:    "done entries are removed",
+			records: []outboxRecord{{Op: outboxAdd, ID: "a", TxID: "a"}, {Op: outboxAdd, ID: "b", TxID: "b"}, {Op: outboxDone, ID: "a"}},
+			pending: []string{"b"},
+			txIDs:   []string{"b"},
+		},
+		{
+			name: "resubmit replaces transaction id",
+			records: []outboxRecord{
+				{Op: outboxAdd, ID: "a", TxID: "a"},
+				{Op: outboxAdd, ID: "b", TxID: "b"},
+				{Op: outboxResubmit, ID: "a", TxID: "a2", Nonce: []byte("nonce")},
+			},
+			pending: []string{"a", "b"},
+			txIDs:   []string{"a2", "b"},
+		},
+		{
+			name:    "resubmit after done is ignored",
+			records: []outboxRecord{{Op: outboxAdd, ID: "a", TxID: "a"}, {Op: outboxDone, ID: "a"}, {Op: outboxResubmit, ID: "a", TxID: "a2"}},
+			pending: nil,
+			txIDs:   nil,
+		},
+		{
+			name:    "compacted log replays the same way",
+			records: []outboxRecord{{Op: outboxAdd, ID: "a", TxID: "a2"}, {Op: outboxAdd, ID: "b", TxID: "b"}},
+			pending: []string{"a", "b"},
+			txIDs:   []string{"a2", "b"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var pending, txIDs []string
+			for _, entry := range pendingOutboxEntries(test.records) {
+				if entry.checked {
+					t.Errorf("entry %s is checked after replay", entry.record.ID)
+				}
+				pending = append(pending, entry.record.ID)
+				txIDs = append(txIDs, entry.record.TxID)
+			}
+			if !reflect.DeepEqual(pending, test.pending) || !reflect.DeepEqual(txIDs, test.txIDs) {
+				t.Fatalf("pending %v with transaction ids %v, expected %v with %v", pending, txIDs, test.pending, test.txIDs)
+			}
+		})
+	}
+}
+
+func TestOutboxCompact(t *testing.T) {
+	path := writeOutboxLog(t, "")
+	outbox := &Outbox{path: path}
+	outbox.entries = pendingOutboxEntries([]outboxRecord{
+		{Op: outboxAdd, ID: "a", TxID: "a"},
+		{Op: outboxAdd, ID: "b", TxID: "b"},
+		{Op: outboxResubmit, ID: "a", TxID: "a2"},
+	})
+	if err := outbox.compact(); err != nil {
+		t.Fatal(err)
+	}
+	if err := outbox.append(outboxRecord{Op: outboxDone, ID: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	outbox.file.Close()
+	records, err := readOutboxLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []outboxRecord{{Op: outboxAdd, ID: "a", TxID: "a2"}, {Op: outboxAdd, ID: "b", TxID: "b"}, {Op: outboxDone, ID: "b"}}
+	if !reflect.DeepEqual(records, expected) {
+		t.Fatalf("log contains %+v, expected %+v", records, expected)
+	}
+	if outbox.records != len(expected) {
+		t.Fatalf("record count is %d, expected %d", outbox.records, len(expected))
+	}
+}
+
+func TestOutboxPostpone(t *testing.T) {
+	outbox := &Outbox{entries: pendingOutboxEntries([]outboxRecord{{Op: outboxAdd, ID: "a"}, {Op: outboxAdd, ID: "b"}, {Op: outboxAdd, ID: "c"}})}
+	outbox.postpone(outbox.next())
+	var ids []string
+	for _, entry := range outbox.entries {
+		ids = append(ids, entry.record.ID)
+	}
+	if expected := []string{"b", "c", "a"}; !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("queue is %v, expected %v", ids, expected)
+	}
+}
+
+func TestFailedDeliveryAction(t *testing.T) {
+	sdkError := func(group status.Group, code int32, message string) error {
+		err := classifyError("chaincode", "tx", status.New(group, code, message, nil))
+		return fmt.Errorf("Failed to invoke chaincode.\n Error: %w", err)
+	}
+	tests := []struct {
+		name   string
+		err    error
+		action outboxAction
+	}{
+		{
+			name:   "duplicate transaction rejected at endorsement is checked on ledger",
+			err:    sdkError(status.EndorserServerStatus, 500, "duplicate transaction found [tx]. Creator [00]"),
+			action: outboxCheckLedger,
+		},
+		{
+			name:   "duplicate transaction invalidated at commit is checked on ledger",
+			err:    sdkError(status.EventServerStatus, int32(pb.TxValidationCode_DUPLICATE_TXID), "duplicate"),
+			action: outboxCheckLedger,
+		},
+		{
+			name:   "read conflict is resubmitted with new id",
+			err:    sdkError(status.EventServerStatus, int32(pb.TxValidationCode_MVCC_READ_CONFLICT), "conflict"),
+			action: outboxResubmitWithNewID,
+		},
+		{
+			name:   "other invalid transaction is reported",
+			err:    sdkError(status.EventServerStatus, int32(pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE), "policy"),
+			action: outboxReportFailure,
+		},
+		{
+			name:   "chaincode error is reported",
+			err:    sdkError(status.ChaincodeStatus, 500, "failed"),
+			action: outboxReportFailure,
+		},
+		{
+			name:   "endorsement rejection is reported",
+			err:    sdkError(status.EndorserServerStatus, 500, "access denied"),
+			action: outboxReportFailure,
+		},
+		{
+			name:   "connection failure is checked on ledger",
+			err:    sdkError(status.EndorserClientStatus, status.ConnectionFailed.ToInt32(), "connection failed"),
+			action: outboxCheckLedger,
+		},
+		{
+			name:   "unknown error is checked on ledger",
+			err:    errors.New("broken pipe"),
+			action: outboxCheckLedger,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if action := failedDeliveryAction(test.err); action != test.action {
+				t.Fatalf("action is %d, expected %d", action, test.action)
+			}
+		})
+	}
+}
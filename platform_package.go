@@ -0,0 +1,202 @@
+package fabclient
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// javaExcludedDirs are build output directories which are not included to Java chaincode package the same way as peer cli does
+var javaExcludedDirs = map[string]bool{
+	"target": true,
+	"build":  true,
+	"out":    true,
+}
+
+// javaExcludedFileTypes are compiled files which are not included to Java chaincode package
+var javaExcludedFileTypes = map[string]bool{
+	".class": true,
+}
+
+// nodeExcludedDirs are dependency directories which are not included to Node.js chaincode package because peer installs dependencies itself
+var nodeExcludedDirs = map[string]bool{
+	"node_modules": true,
+}
+
+// goPackageFileTypes are extensions of files which are included to Go chaincode package the same way as peer cli does
+var goPackageFileTypes = map[string]bool{
+	".c":    true,
+	".h":    true,
+	".s":    true,
+	".go":   true,
+	".yaml": true,
+	".json": true,
+}
+
+// metadataPrefix is directory of chaincode package where metadata is placed
+const metadataPrefix = "META-INF/"
+
+// packageGoPath packs Go chaincode with import path inside GOPATH and its dependencies which are not in standard library
+// as src/<import path> entries in .tar.gz format. META-INF directory of chaincode is packed to root of package
+func packageGoPath(importPath string) ([]byte, error) {
+	cmd := exec.Command("go", "list", "-deps", "-f", "{{if not .Standard}}{{.ImportPath}} {{.Dir}}{{end}}", importPath)
+	cmd.Env = append(os.Environ(), "GO111MODULE=off")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list dependencies of Go chaincode %s inside GOPATH.\n Error: %w\n Output: %s", importPath, err, stderr.String())
+	}
+	var entries []tarEntry
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), " ", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		if fields[0] == importPath {
+			metadataEntries, err := readChaincodeMetadataDir(fields[1])
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, metadataEntries...)
+		}
+		files, err := ioutil.ReadDir(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read directory of Go package %s.\n Error: %w", fields[0], err)
+		}
+		for _, file := range files {
+			if !file.Mode().IsRegular() || !goPackageFileTypes[filepath.Ext(file.Name())] {
+				continue
+			}
+			content, err := ioutil.ReadFile(filepath.Join(fields[1], file.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("Failed to read file %s of Go package %s.\n Error: %w", file.Name(), fields[0], err)
+			}
+			entries = append(entries, tarEntry{name: path.Join("src", fields[0], file.Name()), content: content})
+		}
+	}
+	payload, err := writeTarGz(entries)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create package of Go chaincode %s.\n Error: %w", importPath, err)
+	}
+	return payload, nil
+}
+
+// packageProjectDir packs files of Node.js or Java project as src entries in .tar.gz format. Hidden directories, excluded directories
+// and files with excluded extensions are skipped. Files in META-INF directory are packed as META-INF entries
+func packageProjectDir(projectDir string, excludedDirs map[string]bool, excludedFileTypes map[string]bool) ([]byte, error) {
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+	err := filepath.Walk(projectDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if filePath != projectDir && (strings.HasPrefix(info.Name(), ".") || excludedDirs[info.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() || excludedFileTypes[filepath.Ext(filePath)] {
+			return nil
+		}
+		relativePath, err := filepath.Rel(projectDir, filePath)
+		if err != nil {
+			return err
+		}
+		name := path.Join("src", filepath.ToSlash(relativePath))
+		if strings.HasPrefix(filepath.ToSlash(relativePath), "META-INF/") {
+			name = filepath.ToSlash(relativePath)
+		}
+		return writeFileToPackage(tw, filePath, name)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to pack project directory %s.\n Error: %w", projectDir, err)
+	}
+	if err = tw.Close(); err == nil {
+		err = gw.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to finish package of project directory %s.\n Error: %w", projectDir, err)
+	}
+	return payload.Bytes(), nil
+}
+
+// writeFileToPackage writes file with zeroed times and fixed mode so package does not depend on file system metadata
+func writeFileToPackage(tw *tar.Writer, filePath string, name string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if err = tw.WriteHeader(packageFileHeader(name, info.Size())); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, file)
+	return err
+}
+
+func packageFileHeader(name string, size int64) *tar.Header {
+	return &tar.Header{
+		Name:     name,
+		Size:     size,
+		Mode:     0100644,
+		ModTime:  time.Unix(0, 0),
+		Typeflag: tar.TypeReg,
+	}
+}
+
+type tarEntry struct {
+	name    string
+	content []byte
+}
+
+// readChaincodeMetadataDir reads and validates META-INF directory placed next to chaincode source, so it is packed to root of package
+// the same way as peer cli does. nil is returned if chaincode directory does not contain META-INF
+func readChaincodeMetadataDir(chaincodeDir string) ([]tarEntry, error) {
+	metadataDir := filepath.Join(chaincodeDir, strings.TrimSuffix(metadataPrefix, "/"))
+	if _, err := os.Stat(metadataDir); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return readMetadataDir(metadataDir)
+}
+
+// writeTarGz writes entries sorted by name with the same headers as writeFileToPackage so result is deterministic
+func writeTarGz(entries []tarEntry) ([]byte, error) {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+	for _, entry := range entries {
+		if err := tw.WriteHeader(packageFileHeader(entry.name, int64(len(entry.content)))); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(entry.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return payload.Bytes(), nil
+}
@@ -0,0 +1,147 @@
+package fabclient
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Receipt contains information about committed transaction
+type Receipt struct {
+	TxID            string
+	ValidationCode  pb.TxValidationCode
+	ChaincodeStatus int32
+	Message         string
+	Payload         []byte
+	Endorsers       []Endorser
+	Event           *ChaincodeEvent
+	BlockNumber     uint64
+	// Attempts is number of submissions of transaction including resubmissions after validation failures
+	Attempts int
+}
+
+// Endorser identifies peer which endorsed transaction
+type Endorser struct {
+	URL         string
+	MSPID       string
+	Certificate []byte
+}
+
+// ChaincodeEvent is event emitted by chaincode during transaction
+type ChaincodeEvent struct {
+	ChaincodeID string
+	TxID        string
+	EventName   string
+	Payload     []byte
+	BlockNumber uint64
+}
+
+// committedResponse is response of channel client with number of block which contains transaction
+type committedResponse struct {
+	channel.Response
+	BlockNumber uint64
+}
+
+// commitHandler is the same as commit handler of fabric-sdk-go but keeps number of block which contains transaction.
+// Errors are wrapped with github.com/pkg/errors like in fabric-sdk-go so status of error can be extracted
+type commitHandler struct {
+	blockNumber uint64
+}
+
+func (h *commitHandler) Handle(requestContext *invoke.RequestContext, clientContext *invoke.ClientContext) {
+	txID := string(requestContext.Response.TransactionID)
+	registration, statuses, err := clientContext.EventService.RegisterTxStatusEvent(txID)
+	if err != nil {
+		requestContext.Error = pkgerrors.Wrapf(err, "failed to register for status of transaction %s", txID)
+		return
+	}
+	defer clientContext.EventService.Unregister(registration)
+	tx, err := clientContext.Transactor.CreateTransaction(fab.TransactionRequest{
+		Proposal:          requestContext.Response.Proposal,
+		ProposalResponses: requestContext.Response.Responses,
+	})
+	if err == nil {
+		_, err = clientContext.Transactor.SendTransaction(tx)
+	}
+	if err != nil {
+		requestContext.Error = pkgerrors.Wrapf(err, "failed to send transaction %s to orderer", txID)
+		return
+	}
+	select {
+	case txStatus := <-statuses:
+		requestContext.Response.TxValidationCode = txStatus.TxValidationCode
+		h.blockNumber = txStatus.BlockNumber
+		if txStatus.TxValidationCode != pb.TxValidationCode_VALID {
+			requestContext.Error = status.New(status.EventServerStatus, int32(txStatus.TxValidationCode), "received invalid transaction", nil)
+		}
+	case <-requestContext.Ctx.Done():
+		requestContext.Error = status.New(status.ClientStatus, status.Timeout.ToInt32(), "Execute didn't receive block event", nil)
+	}
+}
+
+func newReceipt(resp committedResponse) (*Receipt, error) {
+	receipt := &Receipt{
+		TxID:            string(resp.TransactionID),
+		ValidationCode:  resp.TxValidationCode,
+		ChaincodeStatus: resp.ChaincodeStatus,
+		Payload:         resp.Payload,
+		BlockNumber:     resp.BlockNumber,
+	}
+	for _, response := range resp.Responses {
+		endorser := Endorser{URL: response.Endorser}
+		if response.ProposalResponse != nil {
+			if response.Response != nil && receipt.Message == "" {
+				receipt.Message = response.Response.Message
+			}
+			if response.Endorsement != nil {
+				identity := &mspproto.SerializedIdentity{}
+				if err := proto.Unmarshal(response.Endorsement.Endorser, identity); err != nil {
+					return nil, fmt.Errorf("Failed to unmarshal identity of endorser %s.\n Error: %w", response.Endorser, err)
+				}
+				endorser.MSPID = identity.Mspid
+				endorser.Certificate = identity.IdBytes
+			}
+		}
+		receipt.Endorsers = append(receipt.Endorsers, endorser)
+	}
+	if len(resp.Responses) > 0 && resp.Responses[0].ProposalResponse != nil {
+		event, err := chaincodeEventFromProposalResponse(resp.Responses[0].ProposalResponse)
+		if err != nil {
+			return nil, err
+		}
+		receipt.Event = event
+	}
+	return receipt, nil
+}
+
+// chaincodeEventFromProposalResponse returns nil if chaincode did not set event
+func chaincodeEventFromProposalResponse(proposalResponse *pb.ProposalResponse) (*ChaincodeEvent, error) {
+	responsePayload := &pb.ProposalResponsePayload{}
+	if err := proto.Unmarshal(proposalResponse.Payload, responsePayload); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal proposal response payload.\n Error: %w", err)
+	}
+	action := &pb.ChaincodeAction{}
+	if err := proto.Unmarshal(responsePayload.Extension, action); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal chaincode action.\n Error: %w", err)
+	}
+	if len(action.Events) == 0 {
+		return nil, nil
+	}
+	event := &pb.ChaincodeEvent{}
+	if err := proto.Unmarshal(action.Events, event); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal chaincode event.\n Error: %w", err)
+	}
+	return &ChaincodeEvent{
+		ChaincodeID: event.ChaincodeId,
+		TxID:        event.TxId,
+		EventName:   event.EventName,
+		Payload:     event.Payload,
+	}, nil
+}
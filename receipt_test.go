@@ -0,0 +1,77 @@
+package fabclient
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+)
+
+// commitEvents delivers status of transaction as soon as it is registered
+type commitEvents struct {
+	fab.EventService
+	status *fab.TxStatusEvent
+}
+
+func (e *commitEvents) RegisterTxStatusEvent(txID string) (fab.Registration, <-chan *fab.TxStatusEvent, error) {
+	statuses := make(chan *fab.TxStatusEvent, 1)
+	statuses <- e.status
+	return nil, statuses, nil
+}
+
+func (e *commitEvents) Unregister(fab.Registration) {}
+
+// commitTransactor accepts every transaction
+type commitTransactor struct {
+	fab.Transactor
+}
+
+func (t *commitTransactor) CreateTransaction(fab.TransactionRequest) (*fab.Transaction, error) {
+	return &fab.Transaction{}, nil
+}
+
+func (t *commitTransactor) SendTransaction(*fab.Transaction) (*fab.TransactionResponse, error) {
+	return &fab.TransactionResponse{}, nil
+}
+
+func TestReceiptCarriesBlockNumber(t *testing.T) {
+	tests := []struct {
+		name           string
+		validationCode pb.TxValidationCode
+		fails          bool
+	}{
+		{name: "valid transaction", validationCode: pb.TxValidationCode_VALID},
+		{name: "invalid transaction", validationCode: pb.TxValidationCode_MVCC_READ_CONFLICT, fails: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := &commitHandler{}
+			requestContext := &invoke.RequestContext{Ctx: context.Background()}
+			requestContext.Response.TransactionID = "tx"
+			clientContext := &invoke.ClientContext{
+				EventService: &commitEvents{status: &fab.TxStatusEvent{TxID: "tx", TxValidationCode: test.validationCode, BlockNumber: 42}},
+				Transactor:   &commitTransactor{},
+			}
+			handler.Handle(requestContext, clientContext)
+			if test.fails {
+				if requestContext.Error == nil {
+					t.Fatal("error is expected")
+				}
+				return
+			}
+			if requestContext.Error != nil {
+				t.Fatal(requestContext.Error)
+			}
+			receipt, err := newReceipt(committedResponse{Response: channel.Response(requestContext.Response), BlockNumber: handler.blockNumber})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if receipt.BlockNumber != 42 || receipt.ValidationCode != pb.TxValidationCode_VALID || receipt.TxID != "tx" {
+				t.Fatalf("unexpected receipt %+v", receipt)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package fabclient
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+)
+
+// ResubmitPolicy defines how invoke is resubmitted with fresh transaction id if transaction is invalidated at commit.
+// Zero policy disables resubmission
+type ResubmitPolicy struct {
+	// MaxAttempts is maximum number of attempts including the first one
+	MaxAttempts int
+	// InitialBackoff is delay before the second attempt
+	InitialBackoff time.Duration
+	// MaxBackoff limits delay between attempts
+	MaxBackoff time.Duration
+	// BackoffFactor multiplies delay after every attempt
+	BackoffFactor float64
+	// Jitter is fraction of delay which is randomized, from 0 to 1
+	Jitter float64
+	// RetryableCodes are validation codes of transactions which are resubmitted
+	RetryableCodes []pb.TxValidationCode
+}
+
+// DefaultResubmitPolicy resubmits transactions with read conflicts up to 5 times
+var DefaultResubmitPolicy = ResubmitPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	BackoffFactor:  2,
+	Jitter:         0.5,
+	RetryableCodes: []pb.TxValidationCode{pb.TxValidationCode_MVCC_READ_CONFLICT, pb.TxValidationCode_PHANTOM_READ_CONFLICT},
+}
+
+// resubmit reports whether transaction failed with err on given attempt should be submitted again
+func (p ResubmitPolicy) resubmit(attempt int, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	var txErr *TransactionError
+	if !errors.As(err, &txErr) {
+		return false
+	}
+	for _, code := range p.RetryableCodes {
+		if code == txErr.ValidationCode {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns delay after given attempt
+func (p ResubmitPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		delay *= p.BackoffFactor
+		if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay -= delay * p.Jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// wait sleeps before next attempt. ctx.Err() is returned if ctx is done earlier
+func (p ResubmitPolicy) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(p.backoff(attempt))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// withoutCommitRetries returns copy of options in which fabric-sdk-go does not retry transactions invalidated at commit if resubmit policy is set.
+// Such transactions are resubmitted by policy only, so every submitted transaction is counted in attempts
+func (o clientOptions) withoutCommitRetries() clientOptions {
+	if o.resubmitPolicy.MaxAttempts == 0 {
+		return o
+	}
+	codes := o.retry.RetryableCodes
+	if len(codes) == 0 {
+		codes = retry.DefaultRetryableCodes
+	}
+	o.retry.RetryableCodes = make(map[status.Group][]status.Code, len(codes))
+	for group, groupCodes := range codes {
+		if group != status.EventServerStatus {
+			o.retry.RetryableCodes[group] = groupCodes
+		}
+	}
+	return o
+}
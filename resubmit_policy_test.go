@@ -0,0 +1,61 @@
+package fabclient
+
+import (
+	"testing"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+)
+
+func TestResubmitPolicyAttemptsAreNotMultipliedBySDKRetries(t *testing.T) {
+	conflict := status.New(status.EventServerStatus, int32(pb.TxValidationCode_MVCC_READ_CONFLICT), "received invalid transaction", nil)
+	tests := []struct {
+		name     string
+		options  clientOptions
+		attempts int
+	}{
+		{name: "without policy fabric-sdk-go retries conflicts", options: newClientOptions(retry.DefaultChannelOpts, nil), attempts: 1 + retry.DefaultChannelOpts.Attempts},
+		{name: "with policy only policy resubmits conflicts", options: newClientOptions(retry.DefaultChannelOpts, []Option{WithResubmitPolicy(DefaultResubmitPolicy)}), attempts: DefaultResubmitPolicy.MaxAttempts},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := test.options.withoutCommitRetries()
+			options.retry.InitialBackoff, options.retry.MaxBackoff = 0, 0
+			// submissions are counted the same way as execute does: every attempt of policy contains attempts of fabric-sdk-go handler
+			submissions := 0
+			for attempt := 1; ; attempt++ {
+				handler := retry.New(options.retry)
+				for {
+					submissions++
+					if !handler.Required(conflict) {
+						break
+					}
+				}
+				err := classifyError("chaincode", "tx", conflict)
+				if !options.resubmitPolicy.resubmit(attempt, err) {
+					if options.resubmitPolicy.MaxAttempts > 0 && attempt != submissions {
+						t.Fatalf("receipt reports %d attempts but %d transactions are submitted", attempt, submissions)
+					}
+					break
+				}
+			}
+			if submissions != test.attempts {
+				t.Fatalf("%d transactions are submitted, expected %d", submissions, test.attempts)
+			}
+		})
+	}
+}
+
+func TestWithoutCommitRetriesKeepsOtherCodes(t *testing.T) {
+	options := newClientOptions(retry.DefaultChannelOpts, []Option{WithResubmitPolicy(DefaultResubmitPolicy)}).withoutCommitRetries()
+	if _, ok := options.retry.RetryableCodes[status.EventServerStatus]; ok {
+		t.Fatal("validation codes are retried by fabric-sdk-go")
+	}
+	if len(options.retry.RetryableCodes[status.EndorserClientStatus]) == 0 {
+		t.Fatal("endorsement codes are not retried by fabric-sdk-go")
+	}
+	if _, ok := retry.ChannelClientRetryableCodes[status.EventServerStatus]; !ok {
+		t.Fatal("default retryable codes are modified")
+	}
+}
@@ -0,0 +1,137 @@
+package fabclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/halfest/fabric-client/blockdecoder"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// TransactionState is state of transaction on channel
+type TransactionState int
+
+const (
+	// TransactionUnknown means that transaction is not found on channel. It may be not committed yet
+	TransactionUnknown TransactionState = iota
+	// TransactionCommitted means that transaction is committed with VALID validation code
+	TransactionCommitted
+	// TransactionInvalid means that transaction is in block but it is invalidated and takes no effect
+	TransactionInvalid
+)
+
+func (s TransactionState) String() string {
+	switch s {
+	case TransactionCommitted:
+		return "committed"
+	case TransactionInvalid:
+		return "invalid"
+	default:
+		return "unknown"
+	}
+}
+
+// TransactionStatus contains state of transaction. ValidationCode, BlockNumber and Timestamp are set if transaction is found
+type TransactionStatus struct {
+	TxID           string
+	State          TransactionState
+	ValidationCode pb.TxValidationCode
+	BlockNumber    uint64
+	Timestamp      time.Time
+}
+
+// GetTransactionStatus returns state of transaction. Transaction which is not found on channel has TransactionUnknown state
+func (c *UserClient) GetTransactionStatus(txID string) (*TransactionStatus, error) {
+	return c.GetTransactionStatusContext(context.Background(), txID)
+}
+
+// GetTransactionStatusContext is the same as GetTransactionStatus but request is bound to ctx
+func (c *UserClient) GetTransactionStatusContext(ctx context.Context, txID string) (*TransactionStatus, error) {
+	transaction, err := c.ledgerClient.QueryTransactionContext(ctx, txID)
+	if errors.Is(err, ErrTransactionNotFound) {
+		return &TransactionStatus{TxID: txID, State: TransactionUnknown}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	status := &TransactionStatus{TxID: txID, ValidationCode: pb.TxValidationCode(transaction.ValidationCode), State: TransactionInvalid}
+	if status.ValidationCode == pb.TxValidationCode_VALID {
+		status.State = TransactionCommitted
+	}
+	decoded, err := blockdecoder.DecodeEnvelope(transaction.TransactionEnvelope)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode transaction %s.\n Error: %w", txID, err)
+	}
+	status.Timestamp = decoded.Timestamp
+	block, err := c.ledgerClient.QueryBlockByTxIDContext(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+	status.BlockNumber = block.Header.Number
+	return status, nil
+}
+
+// WaitForTransaction blocks until transaction is in block and returns its status. Status of transaction which is committed already is returned right away.
+// If ctx is done earlier ctx.Err() is returned. Waiting is not counted as in-flight request by CloseGracefully
+func (c *UserClient) WaitForTransaction(ctx context.Context, txID string) (*TransactionStatus, error) {
+	if err := c.fabricClient.checkOpen(); err != nil {
+		return nil, err
+	}
+	events, err := c.fabricClient.txStatusEvents(c.channelID, c.channelProvider)
+	if err != nil {
+		return nil, err
+	}
+	// registration precedes ledger query so transaction committed in between is not missed
+	statuses, unregister, err := events.register(txID)
+	if err != nil {
+		return nil, err
+	}
+	defer unregister()
+	status, err := c.GetTransactionStatusContext(ctx, txID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	if status.State != TransactionUnknown {
+		return status, nil
+	}
+	select {
+	case event, ok := <-statuses:
+		if !ok {
+			return nil, fmt.Errorf("Event connection is closed before commit of transaction %s", txID)
+		}
+		status = &TransactionStatus{TxID: txID, ValidationCode: event.TxValidationCode, BlockNumber: event.BlockNumber, State: TransactionInvalid}
+		if event.TxValidationCode == pb.TxValidationCode_VALID {
+			status.State = TransactionCommitted
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.fabricClient.shutdown:
+		return nil, ErrClosed
+	}
+	// timestamp is available on ledger only, status from event is returned if peer has not committed block yet
+	if committed, err := c.GetTransactionStatusContext(ctx, txID); err == nil && committed.State != TransactionUnknown {
+		return committed, nil
+	}
+	return status, nil
+}
+
+// GetTransactionStatus returns state of transaction. Transaction which is not found on channel has TransactionUnknown state
+func (c *ChaincodeClient) GetTransactionStatus(txID string) (*TransactionStatus, error) {
+	return c.userClient.GetTransactionStatus(txID)
+}
+
+// GetTransactionStatusContext is the same as GetTransactionStatus but request is bound to ctx
+func (c *ChaincodeClient) GetTransactionStatusContext(ctx context.Context, txID string) (*TransactionStatus, error) {
+	return c.userClient.GetTransactionStatusContext(ctx, txID)
+}
+
+// WaitForTransaction blocks until transaction is in block and returns its status. Status of transaction which is committed already is returned right away.
+// If ctx is done earlier ctx.Err() is returned
+func (c *ChaincodeClient) WaitForTransaction(ctx context.Context, txID string) (*TransactionStatus, error) {
+	return c.userClient.WaitForTransaction(ctx, txID)
+}
@@ -0,0 +1,113 @@
+package fabclient
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/event"
+	sdkcontext "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+)
+
+// txStatusEvents shares event client of channel between commit waiters and chaincode event subscriptions. Event client allows only one
+// registration per transaction id, so one registration is made per transaction and its status is fanned out to all waiters of the transaction
+type txStatusEvents struct {
+	events  eventRegistrar
+	lock    sync.Mutex
+	waiters map[string]*txStatusWaiters
+}
+
+// eventRegistrar is part of event client which registers for statuses of transactions and for chaincode events
+type eventRegistrar interface {
+	RegisterTxStatusEvent(txID string) (fab.Registration, <-chan *fab.TxStatusEvent, error)
+	RegisterChaincodeEvent(chaincodeID string, eventFilter string) (fab.Registration, <-chan *fab.CCEvent, error)
+	Unregister(registration fab.Registration)
+}
+
+// txStatusWaiters contains registration of transaction and channels of its waiters
+type txStatusWaiters struct {
+	registration fab.Registration
+	channels     map[chan *fab.TxStatusEvent]bool
+	closed       bool
+}
+
+func newTxStatusEvents(events eventRegistrar) *txStatusEvents {
+	return &txStatusEvents{events: events, waiters: make(map[string]*txStatusWaiters)}
+}
+
+// txStatusEvents returns event client of channel shared by asynchronous invokes, transaction waiters and chaincode event subscriptions.
+// It receives filtered blocks and is created with identity of the first user which uses it
+func (c *FabricClient) txStatusEvents(channelID string, channelProvider sdkcontext.ChannelProvider) (*txStatusEvents, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if events, ok := c.txEvents[channelID]; ok {
+		return events, nil
+	}
+	eventClient, err := event.New(channelProvider)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create event client with channel id %s.\n Error: %w", channelID, err)
+	}
+	if c.txEvents == nil {
+		c.txEvents = make(map[string]*txStatusEvents)
+	}
+	events := newTxStatusEvents(eventClient)
+	c.txEvents[channelID] = events
+	return events, nil
+}
+
+// register returns channel which receives status of transaction and function which unregisters the waiter.
+// Channel is closed if event connection is closed before status is received
+func (e *txStatusEvents) register(txID string) (<-chan *fab.TxStatusEvent, func(), error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	waiters, ok := e.waiters[txID]
+	if !ok {
+		registration, statuses, err := e.events.RegisterTxStatusEvent(txID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to register for status of transaction %s.\n Error: %w", txID, err)
+		}
+		waiters = &txStatusWaiters{registration: registration, channels: make(map[chan *fab.TxStatusEvent]bool)}
+		e.waiters[txID] = waiters
+		go e.fanOut(txID, waiters, statuses)
+	}
+	channel := make(chan *fab.TxStatusEvent, 1)
+	waiters.channels[channel] = true
+	return channel, func() { e.unregister(txID, waiters, channel) }, nil
+}
+
+// unregister removes waiter and unregisters transaction when its last waiter is removed
+func (e *txStatusEvents) unregister(txID string, waiters *txStatusWaiters, channel chan *fab.TxStatusEvent) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	delete(waiters.channels, channel)
+	if len(waiters.channels) > 0 || waiters.closed {
+		return
+	}
+	waiters.closed = true
+	delete(e.waiters, txID)
+	e.events.Unregister(waiters.registration)
+}
+
+// fanOut sends status of transaction to all its waiters and closes their channels when registration is closed
+func (e *txStatusEvents) fanOut(txID string, waiters *txStatusWaiters, statuses <-chan *fab.TxStatusEvent) {
+	for status := range statuses {
+		e.lock.Lock()
+		for channel := range waiters.channels {
+			select {
+			case channel <- status:
+			default:
+			}
+		}
+		e.lock.Unlock()
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for channel := range waiters.channels {
+		close(channel)
+	}
+	waiters.channels = nil
+	if !waiters.closed {
+		waiters.closed = true
+		delete(e.waiters, txID)
+	}
+}
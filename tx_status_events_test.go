@@ -0,0 +1,142 @@
+package fabclient
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+)
+
+// fakeRegistrar closes channel of registration on unregister the same way as event client does
+type fakeRegistrar struct {
+	lock          sync.Mutex
+	registrations map[string]chan *fab.TxStatusEvent
+}
+
+func newFakeRegistrar() *fakeRegistrar {
+	return &fakeRegistrar{registrations: make(map[string]chan *fab.TxStatusEvent)}
+}
+
+func (r *fakeRegistrar) RegisterTxStatusEvent(txID string) (fab.Registration, <-chan *fab.TxStatusEvent, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.registrations[txID]; ok {
+		return nil, nil, errors.New("registration already exists for TxID")
+	}
+	statuses := make(chan *fab.TxStatusEvent, 1)
+	r.registrations[txID] = statuses
+	return txID, statuses, nil
+}
+
+func (r *fakeRegistrar) RegisterChaincodeEvent(chaincodeID string, eventFilter string) (fab.Registration, <-chan *fab.CCEvent, error) {
+	return nil, nil, errors.New("chaincode events are not supported")
+}
+
+func (r *fakeRegistrar) Unregister(registration fab.Registration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	close(r.registrations[registration.(string)])
+	delete(r.registrations, registration.(string))
+}
+
+func (r *fakeRegistrar) publish(txID string, code pb.TxValidationCode) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.registrations[txID] <- &fab.TxStatusEvent{TxID: txID, TxValidationCode: code, BlockNumber: 7}
+}
+
+func (r *fakeRegistrar) size() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.registrations)
+}
+
+func (e *txStatusEvents) size() int {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return len(e.waiters)
+}
+
+func TestTxStatusEventsFanOut(t *testing.T) {
+	registrar := newFakeRegistrar()
+	events := newTxStatusEvents(registrar)
+	first, unregisterFirst, err := events.register("tx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, unregisterSecond, err := events.register("tx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if registrar.size() != 1 {
+		t.Fatalf("%d registrations are made, expected 1", registrar.size())
+	}
+	registrar.publish("tx", pb.TxValidationCode_VALID)
+	for _, statuses := range []<-chan *fab.TxStatusEvent{first, second} {
+		if status := <-statuses; status.BlockNumber != 7 {
+			t.Fatalf("unexpected status %+v", status)
+		}
+	}
+	unregisterFirst()
+	if registrar.size() != 1 {
+		t.Fatal("transaction is unregistered while it has waiter")
+	}
+	unregisterSecond()
+	if registrar.size() != 0 || events.size() != 0 {
+		t.Fatal("transaction is not unregistered after its last waiter")
+	}
+}
+
+// submitTransactor fails sending of transaction if err is set
+type submitTransactor struct {
+	fab.Transactor
+	err error
+}
+
+func (t *submitTransactor) CreateTransaction(fab.TransactionRequest) (*fab.Transaction, error) {
+	return &fab.Transaction{}, nil
+}
+
+func (t *submitTransactor) SendTransaction(*fab.Transaction) (*fab.TransactionResponse, error) {
+	return &fab.TransactionResponse{}, t.err
+}
+
+func TestSubmitHandlerDoesNotLeakRegistration(t *testing.T) {
+	tests := []struct {
+		name           string
+		sendErr        error
+		abandonBefore  bool
+		abandonAfter   bool
+		registrations  int
+		handlerFailure bool
+	}{
+		{name: "failed send unregisters transaction", sendErr: errors.New("orderer is unavailable"), handlerFailure: true},
+		{name: "failed request unregisters sent transaction", abandonAfter: true},
+		{name: "handler completed after failed request unregisters transaction", abandonBefore: true},
+		{name: "successful request keeps registration", registrations: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registrar := newFakeRegistrar()
+			submit := &submitHandler{events: newTxStatusEvents(registrar)}
+			if test.abandonBefore {
+				submit.abandon()
+			}
+			requestContext := &invoke.RequestContext{}
+			requestContext.Response.TransactionID = "tx"
+			submit.Handle(requestContext, &invoke.ClientContext{Transactor: &submitTransactor{err: test.sendErr}})
+			if (requestContext.Error != nil) != test.handlerFailure {
+				t.Fatalf("unexpected handler error %v", requestContext.Error)
+			}
+			if test.abandonAfter {
+				submit.abandon()
+			}
+			if registrar.size() != test.registrations || submit.events.size() != test.registrations {
+				t.Fatalf("%d registrations are left, expected %d", registrar.size(), test.registrations)
+			}
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package fabclient
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
-	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
+	sdkcontext "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
 )
 
@@ -13,50 +15,180 @@ type UserClient struct {
 	name            string
 	organization    string
 	channelClient   *channel.Client
+	channelProvider sdkcontext.ChannelProvider
 	channelID       string
 	signingIdentity msp.SigningIdentity
+	// ledgerClient queries transaction statuses with identity of user
+	ledgerClient *LedgerClient
+	fabricClient *FabricClient
+	options      clientOptions
 }
 
 // CreateUserClient is the same as  (c *FabricClient) CreateUserClient(channelID string, name string, organization string) but it does not reuse Fabric Client
-func CreateUserClient(configPath string, ordererHost string, channelID string, name string, organization string) (*UserClient, error) {
+func CreateUserClient(configPath string, ordererHost string, channelID string, name string, organization string, opts ...Option) (*UserClient, error) {
 	fabricClient, err := CreateFabricClient(configPath, ordererHost)
 	if err != nil {
 		return nil, err
 	}
-	return fabricClient.CreateUserClient(channelID, name, organization)
+	return fabricClient.CreateUserClient(channelID, name, organization, opts...)
 }
 
-// Invoke triggers invokation of transaction
-func (c *UserClient) Invoke(chaincodeID string, functionName string, args [][]byte) ([]byte, error) {
-	resp, err := c.channelClient.Execute(channel.Request{ChaincodeID: chaincodeID, Fcn: functionName, Args: args},
-		channel.WithRetry(retry.DefaultChannelOpts))
+// withOptions returns copy of client which shares connections but has opts applied. Client itself is returned if there are no opts
+func (c *UserClient) withOptions(opts []Option) *UserClient {
+	if len(opts) == 0 {
+		return c
+	}
+	userClient := *c
+	userClient.options = c.options.with(opts)
+	return &userClient
+}
+
+// Invoke triggers invokation of transaction. opts override options of client for this request
+func (c *UserClient) Invoke(chaincodeID string, functionName string, args [][]byte, opts ...Option) ([]byte, error) {
+	return c.InvokeContext(context.Background(), chaincodeID, functionName, args, opts...)
+}
+
+// InvokeContext is the same as Invoke but request is bound to ctx
+func (c *UserClient) InvokeContext(ctx context.Context, chaincodeID string, functionName string, args [][]byte, opts ...Option) ([]byte, error) {
+	return c.invoke(ctx, c.options.with(opts), channel.Request{ChaincodeID: chaincodeID, Fcn: functionName, Args: args})
+}
+
+// InvokeWithTransient is the same as Invoke but passes transient map to chaincode. Transient data is not stored in transaction, so it is used for private data and secret inputs
+func (c *UserClient) InvokeWithTransient(chaincodeID string, functionName string, args [][]byte, transient map[string][]byte, opts ...Option) ([]byte, error) {
+	return c.InvokeWithTransientContext(context.Background(), chaincodeID, functionName, args, transient, opts...)
+}
+
+// InvokeWithTransientContext is the same as InvokeWithTransient but request is bound to ctx
+func (c *UserClient) InvokeWithTransientContext(ctx context.Context, chaincodeID string, functionName string, args [][]byte, transient map[string][]byte, opts ...Option) ([]byte, error) {
+	return c.invoke(ctx, c.options.with(opts), channel.Request{ChaincodeID: chaincodeID, Fcn: functionName, Args: args, TransientMap: transient})
+}
+
+func (c *UserClient) invoke(ctx context.Context, options clientOptions, request channel.Request) ([]byte, error) {
+	resp, _, err := c.execute(ctx, options, request)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to invoke chaincode %s with funactions %s and arguments %v.\n Error: %v", chaincodeID, functionName, args, err)
+		return nil, err
 	}
 	logger.Debugf("Response on invoke chaincode: %s\n", resp.Payload)
 	return resp.Payload, nil
 }
 
-// Query is the same as Invoke but without sending transaction to orderer so tx does not added to blockchain history. It is used for querying data
-func (c *UserClient) Query(chaincodeID string, functionName string, args [][]byte) ([]byte, error) {
-	resp, err := c.channelClient.Query(channel.Request{ChaincodeID: chaincodeID, Fcn: functionName, Args: args},
-		channel.WithRetry(retry.DefaultChannelOpts))
+// InvokeWithReceipt is the same as Invoke but returns Receipt with transaction id, validation code, endorsers and event of transaction
+func (c *UserClient) InvokeWithReceipt(chaincodeID string, functionName string, args [][]byte, opts ...Option) (*Receipt, error) {
+	return c.InvokeWithReceiptContext(context.Background(), chaincodeID, functionName, args, opts...)
+}
+
+// InvokeWithReceiptContext is the same as InvokeWithReceipt but request is bound to ctx
+func (c *UserClient) InvokeWithReceiptContext(ctx context.Context, chaincodeID string, functionName string, args [][]byte, opts ...Option) (*Receipt, error) {
+	resp, attempts, err := c.execute(ctx, c.options.with(opts), channel.Request{ChaincodeID: chaincodeID, Fcn: functionName, Args: args})
 	if err != nil {
-		return nil, fmt.Errorf("Failed to query chaincode %s with funactions %s and arguments %v.\n Error: %v", chaincodeID, functionName, args, err)
+		return nil, err
 	}
-	logger.Debugf("Response on query chaincode: %s\n", resp.Payload)
-	return resp.Payload, nil
+	receipt, err := newReceipt(resp)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create receipt for transaction %s.\n Error: %w", resp.TransactionID, err)
+	}
+	receipt.Attempts = attempts
+	logger.Debugf("Transaction %s committed in block %d", receipt.TxID, receipt.BlockNumber)
+	return receipt, nil
+}
+
+// execute sends transaction and resubmits it with fresh transaction id according to resubmit policy. Number of attempts is returned.
+// Transient map is never logged
+func (c *UserClient) execute(ctx context.Context, options clientOptions, request channel.Request) (committedResponse, int, error) {
+	chaincodeID, functionName, args := request.ChaincodeID, request.Fcn, request.Args
+	policy := options.resubmitPolicy
+	options = options.withoutCommitRetries()
+	var resp committedResponse
+	attempt := 0
+	err := c.fabricClient.do(ctx, func() error {
+		for attempt = 1; ; attempt++ {
+			var err error
+			if resp, err = c.executeWithBlockNumber(ctx, options, request); err == nil {
+				return nil
+			}
+			if ctx.Err() != nil {
+				return err
+			}
+			err = classifyError(chaincodeID, string(resp.TransactionID), err)
+			if !policy.resubmit(attempt, err) {
+				return fmt.Errorf("Failed to invoke chaincode %s with function %s and arguments %v after %d attempts.\n Error: %w", chaincodeID, functionName, args, attempt, err)
+			}
+			logger.Debugf("Resubmitting transaction of chaincode %s with function %s after attempt %d.\n Error: %v", chaincodeID, functionName, attempt, err)
+			if err := policy.wait(ctx, attempt); err != nil {
+				return err
+			}
+		}
+	})
+	if err != nil {
+		return committedResponse{}, attempt, err
+	}
+	return resp, attempt, nil
+}
+
+// executeWithBlockNumber is the same as Execute of channel client but also returns number of block which contains transaction
+func (c *UserClient) executeWithBlockNumber(ctx context.Context, options clientOptions, request channel.Request) (committedResponse, error) {
+	commit := &commitHandler{}
+	handler := invoke.NewSelectAndEndorseHandler(
+		invoke.NewEndorsementValidationHandler(
+			invoke.NewSignatureValidationHandler(commit),
+		),
+	)
+	resp, err := c.channelClient.InvokeHandler(handler, request, options.executeOptions(ctx)...)
+	if err != nil {
+		// handler may still be running after request is timed out, so block number is read only from completed request
+		return committedResponse{Response: resp}, err
+	}
+	return committedResponse{Response: resp, BlockNumber: commit.blockNumber}, nil
+}
+
+// Query is the same as Invoke but without sending transaction to orderer so tx does not added to blockchain history. It is used for querying data
+func (c *UserClient) Query(chaincodeID string, functionName string, args [][]byte, opts ...Option) ([]byte, error) {
+	return c.QueryContext(context.Background(), chaincodeID, functionName, args, opts...)
+}
+
+// QueryContext is the same as Query but request is bound to ctx
+func (c *UserClient) QueryContext(ctx context.Context, chaincodeID string, functionName string, args [][]byte, opts ...Option) ([]byte, error) {
+	return c.query(ctx, c.options.with(opts), channel.Request{ChaincodeID: chaincodeID, Fcn: functionName, Args: args})
+}
+
+// QueryWithTransient is the same as Query but passes transient map to chaincode
+func (c *UserClient) QueryWithTransient(chaincodeID string, functionName string, args [][]byte, transient map[string][]byte, opts ...Option) ([]byte, error) {
+	return c.QueryWithTransientContext(context.Background(), chaincodeID, functionName, args, transient, opts...)
+}
+
+// QueryWithTransientContext is the same as QueryWithTransient but request is bound to ctx
+func (c *UserClient) QueryWithTransientContext(ctx context.Context, chaincodeID string, functionName string, args [][]byte, transient map[string][]byte, opts ...Option) ([]byte, error) {
+	return c.query(ctx, c.options.with(opts), channel.Request{ChaincodeID: chaincodeID, Fcn: functionName, Args: args, TransientMap: transient})
+}
+
+func (c *UserClient) query(ctx context.Context, options clientOptions, request channel.Request) ([]byte, error) {
+	var payload []byte
+	err := c.fabricClient.do(ctx, func() error {
+		resp, err := c.channelClient.Query(request, options.queryOptions(ctx)...)
+		if err != nil {
+			return fmt.Errorf("Failed to query chaincode %s with function %s and arguments %v.\n Error: %w", request.ChaincodeID, request.Fcn, request.Args, classifyError(request.ChaincodeID, string(resp.TransactionID), err))
+		}
+		logger.Debugf("Response on query chaincode: %s\n", resp.Payload)
+		payload = resp.Payload
+		return nil
+	})
+	return payload, err
 }
 
 // QueryInt is the same as Query but converts result to integer
-func (c *UserClient) QueryInt(chaincodeID string, functionName string, args [][]byte) (int, error) {
-	resp, err := c.Query(chaincodeID, functionName, args)
+func (c *UserClient) QueryInt(chaincodeID string, functionName string, args [][]byte, opts ...Option) (int, error) {
+	return c.QueryIntContext(context.Background(), chaincodeID, functionName, args, opts...)
+}
+
+// QueryIntContext is the same as QueryInt but request is bound to ctx
+func (c *UserClient) QueryIntContext(ctx context.Context, chaincodeID string, functionName string, args [][]byte, opts ...Option) (int, error) {
+	resp, err := c.QueryContext(ctx, chaincodeID, functionName, args, opts...)
 	if err != nil {
 		return 0, err
 	}
 	restInt, err := strconv.Atoi(string(resp))
 	if err != nil {
-		return 0, fmt.Errorf("Failed to convert restonse %v to integer.\n Error: %v", resp, err)
+		return 0, fmt.Errorf("Failed to convert restonse %v to integer.\n Error: %w", resp, err)
 	}
 	return restInt, nil
 }